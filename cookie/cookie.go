@@ -0,0 +1,215 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package cookie provides a way to safely and conveniently extract HTTP cookie
+// values using a defined schema, including optional verification of cookies
+// signed with an HMAC.
+package cookie
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shoenig/go-conceal"
+)
+
+var (
+	ErrNoValue          = errors.New("expected cookie to exist")
+	ErrParseFailure     = errors.New("could not parse cookie")
+	ErrInvalidSignature = errors.New("cookie signature is not valid")
+)
+
+// A Schema describes how a set of cookies should be parsed, keyed by the name
+// of each cookie.
+type Schema map[string]Parser
+
+// A Parser implementation is capable of extracting a value from a cookie. If
+// the cookie is not present in the request, Parse is called with nil.
+type Parser interface {
+	Parse(*http.Cookie) error
+}
+
+// Parse will parse the cookies of r given the cookie names and parsers defined
+// in schema. If more than one cookie of the same name is present, only the
+// first is used.
+func Parse(r *http.Request, schema Schema) error {
+	for _, name := range slices.Sorted(maps.Keys(schema)) {
+		c, err := r.Cookie(name)
+		if errors.Is(err, http.ErrNoCookie) {
+			c = nil
+		}
+
+		if err = schema[name].Parse(c); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrParseFailure, name, err)
+		}
+	}
+	return nil
+}
+
+// String is used to extract a cookie value into a Go string. If the cookie is
+// missing then an error is returned during parsing.
+func String(s *string) Parser {
+	return &stringParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+// StringOr is used to extract a cookie value into a Go string. If the cookie
+// is missing, then the alt value is used instead.
+func StringOr(s *string, alt string) Parser {
+	*s = alt
+	return &stringParser{
+		required:    false,
+		destination: s,
+	}
+}
+
+type stringParser struct {
+	required    bool
+	destination *string
+}
+
+func (p *stringParser) Parse(c *http.Cookie) error {
+	switch {
+	case c == nil && p.required:
+		return ErrNoValue
+	case c == nil:
+		return nil
+	}
+
+	*p.destination = c.Value
+	return nil
+}
+
+// Secret is used to extract a cookie value into a Go conceal.Text, such as a
+// session ID or CSRF token that must never be logged. If the cookie is missing
+// then an error is returned during parsing.
+func Secret(s **conceal.Text) Parser {
+	return &secretParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+type secretParser struct {
+	required    bool
+	destination **conceal.Text
+}
+
+func (p *secretParser) Parse(c *http.Cookie) error {
+	switch {
+	case c == nil && p.required:
+		return ErrNoValue
+	case c == nil:
+		return nil
+	}
+
+	*p.destination = conceal.New(c.Value)
+	return nil
+}
+
+// Int is used to extract a cookie value into a Go int. If the value is not an
+// int or the cookie is missing then an error is returned during parsing.
+func Int(i *int) Parser {
+	return &intParser{
+		required:    true,
+		destination: i,
+	}
+}
+
+// IntOr is used to extract a cookie value into a Go int. If the cookie is
+// missing, then the alt value is used instead.
+func IntOr(i *int, alt int) Parser {
+	*i = alt
+	return &intParser{
+		required:    false,
+		destination: i,
+	}
+}
+
+type intParser struct {
+	required    bool
+	destination *int
+}
+
+func (p *intParser) Parse(c *http.Cookie) error {
+	switch {
+	case c == nil && p.required:
+		return ErrNoValue
+	case c == nil:
+		return nil
+	}
+
+	i, err := strconv.Atoi(c.Value)
+	if err != nil {
+		return err
+	}
+
+	*p.destination = i
+	return nil
+}
+
+// Sign returns value with an HMAC-SHA256 signature appended, suitable for use
+// as the value of the cookie called name, and for verification by Signed.
+//
+// The signature covers the name of the cookie as well as the value, so that a
+// signed value cannot be moved into a different cookie.
+func Sign(key []byte, name, value string) string {
+	return value + "." + signature(key, name, value)
+}
+
+func signature(key []byte, name, value string) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(name))
+	_, _ = mac.Write([]byte{0})
+	_, _ = mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Signed wraps p such that the value of the cookie must have been created by
+// Sign using the same key. The signature is verified and removed before the
+// cookie is passed to p. If the signature is missing or does not match, an
+// error wrapping ErrInvalidSignature is returned during parsing.
+//
+//	cookie.Signed(key, cookie.Secret(&session))
+func Signed(key []byte, p Parser) Parser {
+	return &signedParser{
+		key:    key,
+		parser: p,
+	}
+}
+
+type signedParser struct {
+	key    []byte
+	parser Parser
+}
+
+func (p *signedParser) Parse(c *http.Cookie) error {
+	if c == nil {
+		return p.parser.Parse(nil)
+	}
+
+	idx := strings.LastIndexByte(c.Value, '.')
+	if idx < 0 {
+		return fmt.Errorf("%w: signature is missing", ErrInvalidSignature)
+	}
+
+	value, sig := c.Value[:idx], c.Value[idx+1:]
+	expected := signature(p.key, c.Name, value)
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	verified := *c
+	verified.Value = value
+	return p.parser.Parse(&verified)
+}
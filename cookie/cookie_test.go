@@ -0,0 +1,130 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package cookie
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func newRequest(t *testing.T, cookies ...*http.Cookie) *http.Request {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	must.NoError(t, err)
+	for _, c := range cookies {
+		request.AddCookie(c)
+	}
+	return request
+}
+
+func Test_Parse(t *testing.T) {
+	request := newRequest(t,
+		&http.Cookie{Name: "theme", Value: "dark"},
+		&http.Cookie{Name: "session", Value: "s3cr3t"},
+		&http.Cookie{Name: "visits", Value: "7"},
+	)
+
+	var (
+		theme   string
+		session *conceal.Text
+		visits  int
+		lang    string
+		page    int
+	)
+
+	err := Parse(request, Schema{
+		"theme":   String(&theme),
+		"session": Secret(&session),
+		"visits":  Int(&visits),
+		"lang":    StringOr(&lang, "en"),
+		"page":    IntOr(&page, 1),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "dark", theme)
+	must.Eq(t, "s3cr3t", session.Unveil())
+	must.Eq(t, 7, visits)
+	must.Eq(t, "en", lang)
+	must.Eq(t, 1, page)
+}
+
+func Test_Parse_fail(t *testing.T) {
+	request := newRequest(t,
+		&http.Cookie{Name: "visits", Value: "many"},
+	)
+
+	var visits int
+	err := Parse(request, Schema{
+		"visits": Int(&visits),
+	})
+	must.ErrorIs(t, err, ErrParseFailure)
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	var session *conceal.Text
+	err = Parse(request, Schema{
+		"session": Secret(&session),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+	must.Nil(t, session)
+}
+
+func Test_Signed(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	other := []byte("fedcba9876543210fedcba9876543210")
+
+	request := newRequest(t,
+		&http.Cookie{Name: "session", Value: Sign(key, "session", "abc123")},
+		&http.Cookie{Name: "csrf", Value: Sign(key, "csrf", "tok")},
+	)
+
+	var (
+		session *conceal.Text
+		csrf    string
+		absent  string
+	)
+
+	err := Parse(request, Schema{
+		"session": Signed(key, Secret(&session)),
+		"csrf":    Signed(key, String(&csrf)),
+		"absent":  Signed(key, StringOr(&absent, "none")),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "abc123", session.Unveil())
+	must.Eq(t, "tok", csrf)
+	must.Eq(t, "none", absent)
+
+	// wrong key
+	err = Parse(request, Schema{
+		"session": Signed(other, Secret(&session)),
+	})
+	must.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func Test_Signed_tampered(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	cases := map[string]string{
+		"modified value": "admin." + signature(key, "session", "user"),
+		"unsigned":       "admin",
+		"moved cookie":   Sign(key, "other", "admin"),
+		"bad signature":  "admin.AAAA",
+	}
+
+	for name, value := range cases {
+		t.Run(name, func(t *testing.T) {
+			request := newRequest(t, &http.Cookie{Name: "session", Value: value})
+
+			var session string
+			err := Parse(request, Schema{
+				"session": Signed(key, String(&session)),
+			})
+			must.ErrorIs(t, err, ErrInvalidSignature)
+			must.Eq(t, "", session)
+		})
+	}
+}
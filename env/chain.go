@@ -0,0 +1,85 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Chain returns a ChainEnvironment which looks up environment variables in
+// each of the given layers, in order of precedence.
+//
+//	e := env.Chain(env.OS, env.OptionalFile(".env.local"), env.File(".env"))
+func Chain(layers ...Environment) *ChainEnvironment {
+	return &ChainEnvironment{layers: layers}
+}
+
+// ChainEnvironment is an implementation of Environment composed of layers of
+// other Environment implementations. The value of a variable is provided by
+// the first layer in which the variable is not empty.
+type ChainEnvironment struct {
+	layers []Environment
+}
+
+// Getenv returns the first non-empty value of name among the layers.
+func (c *ChainEnvironment) Getenv(name string) string {
+	_, value := c.lookup(name)
+	return value
+}
+
+// Source returns the layer that supplies the value of v, or nil if v is not
+// set or is empty in every layer. Useful for debugging where the value of a
+// variable is coming from.
+func (c *ChainEnvironment) Source(v Variable) Environment {
+	layer, _ := c.lookup(v.Name())
+	return layer
+}
+
+// Lookup returns the first non-empty value of name among the layers. If name
+// is empty in every layer, it is considered to be set if it is set to the
+// empty string in any layer.
+func (c *ChainEnvironment) Lookup(name string) (string, bool) {
+	if _, value := c.lookup(name); value != "" {
+		return value, true
+	}
+	for _, layer := range c.layers {
+		if _, exists := Lookup(layer, name); exists {
+			return "", true
+		}
+	}
+	return "", false
+}
+
+func (c *ChainEnvironment) lookup(name string) (Environment, string) {
+	for _, layer := range c.layers {
+		if value := layer.Getenv(name); value != "" {
+			return layer, value
+		}
+	}
+	return nil, ""
+}
+
+// Load loads each layer that is a Loader. Every layer is loaded, and any
+// errors are returned together.
+func (c *ChainEnvironment) Load() error {
+	var errs []error
+	for _, layer := range c.layers {
+		if loader, ok := layer.(Loader); ok {
+			if err := loader.Load(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to load %v: %w", layer, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *ChainEnvironment) String() string {
+	names := make([]string, 0, len(c.layers))
+	for _, layer := range c.layers {
+		names = append(names, fmt.Sprint(layer))
+	}
+	return "chain(" + strings.Join(names, ", ") + ")"
+}
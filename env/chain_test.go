@@ -0,0 +1,78 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Chain(t *testing.T) {
+	dir := t.TempDir()
+	local := filepath.Join(dir, ".env.local")
+	defaults := filepath.Join(dir, ".env")
+	must.NoError(t, os.WriteFile(local, []byte("TWO=local\nTHREE=\n"), 0644))
+	must.NoError(t, os.WriteFile(defaults, []byte("TWO=default\nTHREE=default\nFOUR=default\n"), 0644))
+
+	t.Setenv("ONE", "os")
+	t.Setenv("TWO", "")
+
+	localFile := File(local)
+	defaultsFile := File(defaults)
+	testMap := Map(map[string]string{
+		"FIVE": "map",
+	})
+
+	c := Chain(OS, localFile, defaultsFile, testMap)
+
+	must.Eq(t, "os", c.Getenv("ONE"))
+	must.Eq(t, "local", c.Getenv("TWO"))
+	must.Eq(t, "default", c.Getenv("THREE"))
+	must.Eq(t, "default", c.Getenv("FOUR"))
+	must.Eq(t, "map", c.Getenv("FIVE"))
+	must.Eq(t, "", c.Getenv("SIX"))
+
+	must.Eq[Environment](t, OS, c.Source("ONE"))
+	must.Eq[Environment](t, localFile, c.Source("TWO"))
+	must.Eq[Environment](t, defaultsFile, c.Source("THREE"))
+	must.Eq[Environment](t, testMap, c.Source("FIVE"))
+	must.Nil(t, c.Source("SIX"))
+
+	must.Eq(t, "chain(os, file("+local+"), file("+defaults+"), map)", c.String())
+
+	var (
+		one  string
+		four string
+	)
+	err := Parse(c, Schema{
+		"ONE":  String(&one, true),
+		"FOUR": String(&four, true),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "os", one)
+	must.Eq(t, "default", four)
+}
+
+func Test_Chain_load_errors(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.env")
+
+	c := Chain(OptionalFile(missing), Map(map[string]string{"ONE": "1"}))
+	must.NoError(t, c.Load())
+	must.Eq(t, "1", c.Getenv("ONE"))
+
+	c = Chain(File(missing), Map(map[string]string{"ONE": "1"}))
+	must.ErrorIs(t, c.Load(), fs.ErrNotExist)
+
+	var one string
+	err := Parse(c, Schema{
+		"ONE": String(&one, true),
+	})
+	must.ErrorIs(t, err, fs.ErrNotExist)
+	must.Eq(t, "", one)
+}
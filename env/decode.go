@@ -0,0 +1,213 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+)
+
+// Decode uses the struct tags of the struct pointed to by target to parse the
+// environment variables in the given Environment into the fields of target.
+//
+// Fields are described with an env tag naming the environment variable, and
+// optionally a default tag providing a value to use when the environment
+// variable is not set or is empty.
+//
+//	type Config struct {
+//	  Host     string        `env:"HOST,required"`
+//	  Port     int           `env:"PORT" default:"8080"`
+//	  Password *conceal.Text `env:"PASSWORD,required"`
+//	  Database struct {
+//	    Name string `env:"NAME"` // parsed from DB_NAME
+//	  } `env:"DB"`
+//	}
+//
+// The env tag may include the option required, to report an error when the
+// variable is not set or is empty, and the option allowempty, to consider a
+// variable set to the empty string as set (see AllowEmpty), in which case the
+// default is only used when the variable is not set.
+//
+// Supported field types are string, int, float64, bool, *conceal.Text,
+// time.Duration, and time.Time, which are parsed using String, Int, Float,
+// Bool, Secret, Duration, and Time respectively. The layout of a time.Time
+// field may be set with a layout tag, otherwise time.RFC3339 is used. The
+// sized integer types and float32 are parsed using Integer and Floating.
+//
+// Also supported are []string, []int, and []float64, which are parsed as comma
+// separated lists using Strings, Ints, and Floats, and map[string]string,
+// map[string]int, and map[string]float64, which are parsed as comma separated
+// lists of key:value pairs using StringMap, IntMap, and FloatMap.
+//
+// Nested struct fields are decoded recursively; if a nested struct field has
+// an env tag, its name followed by an underscore is used as a prefix for the
+// names of the variables in the nested struct. Fields without an env tag, or
+// with the tag env:"-", are ignored.
+//
+// Variables are parsed as with Parse, and so all failures are reported
+// together as Errors.
+func Decode(environment Environment, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil pointer to a struct, got %T", target)
+	}
+
+	schema := make(Schema)
+	if err := decodeStruct(schema, "", rv.Elem()); err != nil {
+		return err
+	}
+	return Parse(environment, schema)
+}
+
+// DecodeOS is a convenience function for decoding environment variables
+// accessed by the standard library os package into target.
+func DecodeOS(target any) error {
+	return Decode(OS, target)
+}
+
+func decodeStruct(schema Schema, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("env")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			nested := prefix
+			if name != "" {
+				nested = prefix + name + "_"
+			}
+			if err := decodeStruct(schema, nested, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !tagged {
+			continue
+		}
+
+		if name == "" {
+			return fmt.Errorf("field %s: env tag is missing a variable name", field.Name)
+		}
+
+		required, allowEmpty := false, false
+		for _, option := range strings.Split(options, ",") {
+			switch option {
+			case "":
+			case "required":
+				required = true
+			case "allowempty":
+				allowEmpty = true
+			default:
+				return fmt.Errorf("field %s: unknown env tag option %q", field.Name, option)
+			}
+		}
+
+		parser, err := parserFor(fv, field.Tag.Get("layout"), required)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if allowEmpty {
+			parser = AllowEmpty(parser)
+		}
+
+		if value, exists := field.Tag.Lookup("default"); exists {
+			parser = &defaultParser{value: value, allowEmpty: allowEmpty, parser: parser}
+		}
+
+		key := Variable(prefix + name)
+		if _, exists := schema[key]; exists {
+			return fmt.Errorf("field %s: variable %q is declared more than once", field.Name, key)
+		}
+		schema[key] = parser
+	}
+	return nil
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func parserFor(v reflect.Value, layout string, required bool) (Parser, error) {
+	switch destination := v.Addr().Interface().(type) {
+	case *string:
+		return String(destination, required), nil
+	case *int:
+		return Int(destination, required), nil
+	case *float64:
+		return Float(destination, required), nil
+	case *bool:
+		return Bool(destination, required), nil
+	case *int8:
+		return Integer(destination, required), nil
+	case *int16:
+		return Integer(destination, required), nil
+	case *int32:
+		return Integer(destination, required), nil
+	case *int64:
+		return Integer(destination, required), nil
+	case *uint:
+		return Integer(destination, required), nil
+	case *uint8:
+		return Integer(destination, required), nil
+	case *uint16:
+		return Integer(destination, required), nil
+	case *uint32:
+		return Integer(destination, required), nil
+	case *uint64:
+		return Integer(destination, required), nil
+	case *float32:
+		return Floating(destination, required), nil
+	case **conceal.Text:
+		return Secret(destination, required), nil
+	case *time.Duration:
+		return Duration(destination, required), nil
+	case *time.Time:
+		return Time(destination, layout, required), nil
+	case *[]string:
+		return Strings(destination, required), nil
+	case *[]int:
+		return Ints(destination, required), nil
+	case *[]float64:
+		return Floats(destination, required), nil
+	case *map[string]string:
+		return StringMap(destination, required), nil
+	case *map[string]int:
+		return IntMap(destination, required), nil
+	case *map[string]float64:
+		return FloatMap(destination, required), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+type defaultParser struct {
+	value      string
+	allowEmpty bool
+	parser     Parser
+}
+
+func (dp *defaultParser) Parse(s string) error {
+	return dp.ParseLookup(s, s != "")
+}
+
+func (dp *defaultParser) ParseLookup(s string, exists bool) error {
+	if !exists || (s == "" && !dp.allowEmpty) {
+		s, exists = dp.value, true
+	}
+	return parse(dp.parser, s, exists)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+type database struct {
+	Name string `env:"NAME,required"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type config struct {
+	Host     string        `env:"HOST,required"`
+	Port     int           `env:"PORT" default:"8080"`
+	Ratio    float64       `env:"RATIO"`
+	Debug    bool          `env:"DEBUG"`
+	Password *conceal.Text `env:"PASSWORD,required"`
+	Ignored  string        `env:"-"`
+	Untagged string
+	Database database `env:"DB"`
+	Flat     struct {
+		Region string `env:"REGION"`
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	environment := Map(map[string]string{
+		"HOST":     "example.com",
+		"RATIO":    "0.5",
+		"DEBUG":    "true",
+		"PASSWORD": "hunter2",
+		"Ignored":  "nope",
+		"Untagged": "nope",
+		"DB_NAME":  "users",
+		"DB_PORT":  "6543",
+		"REGION":   "us-east",
+	})
+
+	var c config
+	err := Decode(environment, &c)
+	must.NoError(t, err)
+	must.Eq(t, "example.com", c.Host)
+	must.Eq(t, 8080, c.Port)
+	must.Eq(t, 0.5, c.Ratio)
+	must.True(t, c.Debug)
+	must.Eq(t, "hunter2", c.Password.Unveil())
+	must.Eq(t, "", c.Ignored)
+	must.Eq(t, "", c.Untagged)
+	must.Eq(t, "users", c.Database.Name)
+	must.Eq(t, 6543, c.Database.Port)
+	must.Eq(t, "us-east", c.Flat.Region)
+}
+
+func Test_Decode_missing(t *testing.T) {
+	environment := Map(map[string]string{
+		"PORT": "abc",
+	})
+
+	var c config
+	err := Decode(environment, &c)
+	must.Error(t, err)
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.Len(t, 4, errs)
+	must.Eq(t, "DB_NAME", errs[0].Variable)
+	must.Eq(t, "HOST", errs[1].Variable)
+	must.Eq(t, "PASSWORD", errs[2].Variable)
+	must.Eq(t, "PORT", errs[3].Variable)
+	must.ErrorIs(t, errs[0], ErrMissing)
+}
+
+func Test_Decode_allowempty(t *testing.T) {
+	var target struct {
+		A string `env:"A,allowempty" default:"default"`
+		B string `env:"B,allowempty" default:"default"`
+		C string `env:"C" default:"default"`
+	}
+
+	err := Decode(Map(map[string]string{"A": "", "C": ""}), &target)
+	must.NoError(t, err)
+	must.Eq(t, "", target.A)
+	must.Eq(t, "default", target.B)
+	must.Eq(t, "default", target.C)
+}
+
+func Test_Decode_collections(t *testing.T) {
+	var target struct {
+		Origins []string          `env:"ORIGINS"`
+		Ports   []int             `env:"PORTS" default:"80,443"`
+		Labels  map[string]string `env:"LABELS"`
+	}
+
+	err := Decode(Map(map[string]string{
+		"ORIGINS": "a,b",
+		"LABELS":  "team:core",
+	}), &target)
+	must.NoError(t, err)
+	must.Eq(t, []string{"a", "b"}, target.Origins)
+	must.Eq(t, []int{80, 443}, target.Ports)
+	must.Eq(t, map[string]string{"team": "core"}, target.Labels)
+}
+
+func Test_Decode_time(t *testing.T) {
+	var target struct {
+		Timeout time.Duration `env:"TIMEOUT" default:"30s"`
+		Start   time.Time     `env:"START,required"`
+		Date    time.Time     `env:"DATE" layout:"2006-01-02"`
+	}
+
+	err := Decode(Map(map[string]string{
+		"START": "2024-05-06T07:08:09Z",
+		"DATE":  "2024-05-06",
+	}), &target)
+	must.NoError(t, err)
+	must.Eq(t, 30*time.Second, target.Timeout)
+	must.Eq(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), target.Start)
+	must.Eq(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), target.Date)
+}
+
+func Test_Decode_invalid(t *testing.T) {
+	environment := Map(nil)
+
+	t.Run("not a pointer", func(t *testing.T) {
+		err := Decode(environment, config{})
+		must.ErrorContains(t, err, "must be a non-nil pointer to a struct")
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		var target struct {
+			C complex128 `env:"C"`
+		}
+		err := Decode(environment, &target)
+		must.EqError(t, err, "field C: unsupported type complex128")
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		var target struct {
+			S string `env:"S,optional"`
+		}
+		err := Decode(environment, &target)
+		must.EqError(t, err, `field S: unknown env tag option "optional"`)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		var target struct {
+			A string `env:"X"`
+			B string `env:"X"`
+		}
+		err := Decode(environment, &target)
+		must.EqError(t, err, `field B: variable "{X}" is declared more than once`)
+	})
+}
@@ -0,0 +1,359 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// A SyntaxError describes a malformed line in a .env file.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// readDotenv reads and parses the .env file at filename.
+func readDotenv(filename string) (map[string]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	values, err := parseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return values, nil
+}
+
+// parseDotenv parses the content of r using the de-facto .env file grammar,
+// as implemented by docker compose and the Ruby and Node dotenv libraries.
+//
+//	# comments and blank lines are ignored
+//	KEY=value             # unquoted, with trailing comments and whitespace removed
+//	export KEY=value      # the export prefix is ignored
+//	EMPTY=                # set to the empty string
+//	SINGLE='${literal}'   # no escapes or interpolation
+//	DOUBLE="a\nb ${KEY}"  # escapes and interpolation, may span multiple lines
+//	REF=${KEY:-default}   # default when KEY is unset or empty
+//	REF=${KEY-default}    # default when KEY is unset
+//	REF=$KEY
+//
+// Variable references are resolved against the values defined earlier in the
+// file, falling back to the environment of the current process.
+func parseDotenv(r io.Reader) (map[string]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotenvParser{
+		input:  strings.ReplaceAll(string(b), "\r\n", "\n"),
+		line:   1,
+		values: make(map[string]string),
+	}
+
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+	return p.values, nil
+}
+
+type dotenvParser struct {
+	input  string
+	pos    int
+	line   int
+	values map[string]string
+}
+
+func (p *dotenvParser) errorf(format string, args ...any) error {
+	return &SyntaxError{Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *dotenvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *dotenvParser) skipSpaces() {
+	for c := p.peek(); c == ' ' || c == '\t'; c = p.peek() {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) parse() error {
+	for {
+		p.skipSpaces()
+		switch {
+		case p.eof():
+			return nil
+		case p.peek() == '\n':
+			p.pos++
+			p.line++
+		case p.peek() == '#':
+			p.skipLine()
+		default:
+			if err := p.assignment(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *dotenvParser) assignment() error {
+	key := p.key()
+	if key == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+		if isKeyStart(p.peek()) {
+			key = p.key()
+		}
+	}
+
+	if key == "" {
+		return p.errorf("expected variable name, found %q", p.peek())
+	}
+
+	p.skipSpaces()
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after variable name %q", key)
+	}
+	p.pos++
+	p.skipSpaces()
+
+	var (
+		value string
+		err   error
+	)
+
+	switch p.peek() {
+	case '\'':
+		value, err = p.singleQuoted()
+	case '"':
+		value, err = p.doubleQuoted()
+	default:
+		value, err = p.unquoted()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.values[key] = value
+	return nil
+}
+
+func isKeyStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isKeyStart(c) || (c >= '0' && c <= '9')
+}
+
+func isKeyChar(c byte) bool {
+	return isNameChar(c) || c == '.' || c == '-'
+}
+
+func (p *dotenvParser) key() string {
+	if !isKeyStart(p.peek()) {
+		return ""
+	}
+	start := p.pos
+	for !p.eof() && isKeyChar(p.peek()) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// endOfValue consumes the remainder of the line after a quoted value, which
+// may only contain whitespace and a comment.
+func (p *dotenvParser) endOfValue() error {
+	p.skipSpaces()
+	switch p.peek() {
+	case 0, '\n':
+		return nil
+	case '#':
+		p.skipLine()
+		return nil
+	default:
+		return p.errorf("unexpected character %q after quoted value", p.peek())
+	}
+}
+
+func (p *dotenvParser) singleQuoted() (string, error) {
+	p.pos++ // opening quote
+	end := strings.IndexByte(p.input[p.pos:], '\'')
+	if end < 0 {
+		return "", p.errorf("unterminated single-quoted value")
+	}
+
+	value := p.input[p.pos : p.pos+end]
+	p.line += strings.Count(value, "\n")
+	p.pos += end + 1
+	return value, p.endOfValue()
+}
+
+func (p *dotenvParser) doubleQuoted() (string, error) {
+	p.pos++ // opening quote
+	start := p.line
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", &SyntaxError{Line: start, Msg: "unterminated double-quoted value"}
+		}
+
+		c := p.input[p.pos]
+		p.pos++
+
+		switch c {
+		case '"':
+			return sb.String(), p.endOfValue()
+		case '\n':
+			p.line++
+			sb.WriteByte(c)
+		case '\\':
+			if p.eof() {
+				continue
+			}
+			escaped := p.input[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\', '$':
+				sb.WriteByte(escaped)
+			case '\n':
+				p.line++ // line continuation
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(escaped)
+			}
+		case '$':
+			value, n, err := p.reference(p.input[p.pos:])
+			if err != nil {
+				return "", err
+			}
+			p.pos += n
+			sb.WriteString(value)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+func (p *dotenvParser) unquoted() (string, error) {
+	start := p.pos
+	p.skipLine()
+	raw := p.input[start:p.pos]
+
+	// a comment begins with a '#' at the start of the value, or preceded by
+	// whitespace; otherwise '#' is part of the value
+	if strings.HasPrefix(raw, "#") {
+		raw = ""
+	} else if idx := strings.IndexAny(raw, " \t"); idx >= 0 {
+		for i := idx; i < len(raw); i++ {
+			if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+				raw = raw[:i]
+				break
+			}
+		}
+	}
+	raw = strings.TrimRight(raw, " \t")
+
+	var sb strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '$' {
+			sb.WriteByte(raw[i])
+			continue
+		}
+		value, n, err := p.reference(raw[i+1:])
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(value)
+		i += n
+	}
+	return sb.String(), nil
+}
+
+// reference expands the variable reference at the start of s, which follows
+// a '$' character. The number of bytes of s that were consumed is returned
+// along with the expanded value. A '$' that does not begin a reference is
+// returned as is.
+func (p *dotenvParser) reference(s string) (string, int, error) {
+	if !strings.HasPrefix(s, "{") {
+		if s == "" || !isKeyStart(s[0]) {
+			return "$", 0, nil
+		}
+		n := 1
+		for n < len(s) && isNameChar(s[n]) {
+			n++
+		}
+		value, _ := p.lookup(s[:n])
+		return value, n, nil
+	}
+
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return "", 0, p.errorf("unterminated variable reference")
+	}
+
+	body := s[1:end]
+	n := 0
+	for n < len(body) && isNameChar(body[n]) {
+		n++
+	}
+	name, rest := body[:n], body[n:]
+	if name == "" || !isKeyStart(name[0]) {
+		return "", 0, p.errorf("invalid variable reference %q", "${"+body+"}")
+	}
+
+	value, exists := p.lookup(name)
+	switch {
+	case rest == "":
+	case strings.HasPrefix(rest, ":-"):
+		if value == "" {
+			value = rest[2:]
+		}
+	case strings.HasPrefix(rest, "-"):
+		if !exists {
+			value = rest[1:]
+		}
+	default:
+		return "", 0, p.errorf("invalid variable reference %q", "${"+body+"}")
+	}
+	return value, end + 1, nil
+}
+
+func (p *dotenvParser) lookup(name string) (string, bool) {
+	if value, exists := p.values[name]; exists {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
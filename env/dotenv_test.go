@@ -0,0 +1,143 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_parseDotenv(t *testing.T) {
+	t.Setenv("FROM_OS", "os")
+
+	text := `
+# a comment
+PLAIN=value
+SPACED = spaced value
+export EXPORTED=exported
+EMPTY=
+COMMENT=value # a comment
+HASH=value#not-a-comment
+ONLY_COMMENT= # a comment
+SINGLE='single # ${PLAIN} \n'
+DOUBLE="double # ${PLAIN} \n \"quoted\" \$PLAIN"
+MULTI="line one
+line two"
+SINGLE_MULTI='line one
+line two'
+INTERP=${PLAIN}-$PLAIN
+UNSET_DEFAULT=${NOPE:-fallback}
+EMPTY_DEFAULT=${EMPTY:-fallback}
+EMPTY_DASH=${EMPTY-fallback}
+UNSET_DASH=${NOPE-fallback}
+OS=${FROM_OS}
+DOLLAR=cost $5
+dotted.key-name=ok
+CRLF=crlf` + "\r\n" + `LAST=last`
+
+	values, err := parseDotenv(strings.NewReader(text))
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{
+		"PLAIN":           "value",
+		"SPACED":          "spaced value",
+		"EXPORTED":        "exported",
+		"EMPTY":           "",
+		"COMMENT":         "value",
+		"HASH":            "value#not-a-comment",
+		"ONLY_COMMENT":    "",
+		"SINGLE":          `single # ${PLAIN} \n`,
+		"DOUBLE":          "double # value \n \"quoted\" $PLAIN",
+		"MULTI":           "line one\nline two",
+		"SINGLE_MULTI":    "line one\nline two",
+		"INTERP":          "value-value",
+		"UNSET_DEFAULT":   "fallback",
+		"EMPTY_DEFAULT":   "fallback",
+		"EMPTY_DASH":      "",
+		"UNSET_DASH":      "fallback",
+		"OS":              "os",
+		"DOLLAR":          "cost $5",
+		"dotted.key-name": "ok",
+		"CRLF":            "crlf",
+		"LAST":            "last",
+	}, values)
+}
+
+func Test_parseDotenv_malformed(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		line int
+		msg  string
+	}{
+		{
+			name: "no equals",
+			text: "A=1\nNOVALUE\n",
+			line: 2,
+			msg:  `expected '=' after variable name "NOVALUE"`,
+		},
+		{
+			name: "bad name",
+			text: "1A=1",
+			line: 1,
+			msg:  `expected variable name, found '1'`,
+		},
+		{
+			name: "unterminated single",
+			text: "A='abc\n",
+			line: 1,
+			msg:  "unterminated single-quoted value",
+		},
+		{
+			name: "unterminated double",
+			text: "\nA=\"abc\nB=1\n",
+			line: 2,
+			msg:  "unterminated double-quoted value",
+		},
+		{
+			name: "trailing garbage",
+			text: `A="abc" def`,
+			line: 1,
+			msg:  `unexpected character 'd' after quoted value`,
+		},
+		{
+			name: "unterminated reference",
+			text: "A=${B",
+			line: 1,
+			msg:  "unterminated variable reference",
+		},
+		{
+			name: "invalid reference",
+			text: "A=${B?}",
+			line: 1,
+			msg:  `invalid variable reference "${B?}"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseDotenv(strings.NewReader(tc.text))
+			var se *SyntaxError
+			must.True(t, errors.As(err, &se))
+			must.Eq(t, tc.line, se.Line)
+			must.Eq(t, tc.msg, se.Msg)
+		})
+	}
+}
+
+func Test_ParseFile_malformed(t *testing.T) {
+	temp := filepath.Join(t.TempDir(), "test.env")
+	must.NoError(t, os.WriteFile(temp, []byte("ONE=1\nTWO\n"), 0644))
+
+	var one int
+	err := ParseFile(temp, Schema{
+		"ONE": Int(&one, true),
+	})
+	must.ErrorContains(t, err, "line 2: expected '='")
+	must.Eq(t, 0, one)
+}
@@ -4,17 +4,23 @@
 package env
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
+	"io/fs"
 	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/shoenig/go-conceal"
 )
 
+// ErrMissing indicates a required environment variable is not set or is empty.
+var ErrMissing = errors.New("missing")
+
 // A Variable represents an environment variable.
 type Variable string
 
@@ -39,9 +45,10 @@ func ParseOS(schema Schema) error {
 
 // ParseFile is a convenience function for parsing the given Schema of environment
 // variables using the given .env file path. The contents of the file are read
-// and interpreted as key=value pairs, one per line. If the environment variable
-// contents of the file do not match the schema, or required variables are missing,
-// an error is returned.
+// and interpreted using the .env file format supported by docker compose and
+// the dotenv libraries. If the file cannot be read or is malformed, or if the
+// environment variable contents of the file do not match the schema, or
+// required variables are missing, an error is returned.
 func ParseFile(path string, schema Schema) error {
 	return Parse(File(path), schema)
 }
@@ -57,30 +64,141 @@ func ParseMap(m map[string]string, schema Schema) error {
 // Parse uses the given Schema to parse the environment variables in the given
 // Environment. If the values of environment variables in Environment do not
 // match the schema, or required variables are missing, an error is returned.
+//
+// Every Variable in the Schema is parsed, even after a failure. The returned
+// error is of type Errors, containing one VariableError for each Variable
+// that failed to parse, sorted by Variable name.
+//
+// If environment is a Loader, it is loaded first, and any error loading it is
+// returned before any variables are parsed.
 func Parse(environment Environment, schema Schema) error {
-	for key, parser := range schema {
-		value := environment.Getenv(key.Name())
-		if err := parser.Parse(value); err != nil {
-			return fmt.Errorf("failed to parse %q: %w", key, err)
+	if loader, ok := environment.(Loader); ok {
+		if err := loader.Load(); err != nil {
+			return err
 		}
 	}
+
+	var errs Errors
+	for _, key := range slices.Sorted(maps.Keys(schema)) {
+		value, exists := Lookup(environment, key.Name())
+		if err := parse(schema[key], value, exists); err != nil {
+			errs = append(errs, &VariableError{Variable: key, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
+// A VariableError describes the failure to parse a single Variable.
+type VariableError struct {
+	Variable Variable
+	Err      error
+}
+
+func (e *VariableError) Error() string {
+	return fmt.Sprintf("failed to parse %q: %v", e.Variable, e.Err)
+}
+
+// Unwrap returns the underlying cause of the failure.
+func (e *VariableError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is the collection of every VariableError encountered while parsing
+// a Schema, sorted by Variable name.
+type Errors []*VariableError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns each VariableError, enabling the use of errors.Is and
+// errors.As on the underlying causes.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 // The Parser interface is what must be implemented to support decoding an
 // environment variable into a custom type.
 type Parser interface {
 	Parse(string) error
 }
 
+// A LookupParser is a Parser that is also able to distinguish an environment
+// variable that is not set from one that is set to the empty string. When
+// parsing a Schema, ParseLookup is used instead of Parse for any Parser that
+// implements LookupParser.
+type LookupParser interface {
+	Parser
+	ParseLookup(value string, exists bool) error
+}
+
+func parse(p Parser, value string, exists bool) error {
+	if lp, ok := p.(LookupParser); ok {
+		return lp.ParseLookup(value, exists)
+	}
+	return p.Parse(value)
+}
+
+// emptier is implemented by parsers that can set their destination to an
+// empty value, used when an environment variable is explicitly set to the
+// empty string.
+type emptier interface {
+	empty()
+}
+
+// AllowEmpty wraps p such that an environment variable that is set to the
+// empty string is considered to be set. A required Parser is satisfied, and
+// the destination of p is set to its zero value, overriding any alt value.
+// An environment variable that is not set is still handled by p.
+//
+//	// FOO=  results in foo == ""
+//	// unset results in foo == "default"
+//	env.AllowEmpty(env.StringOr(&foo, "default"))
+func AllowEmpty(p Parser) Parser {
+	return &allowEmptyParser{parser: p}
+}
+
+type allowEmptyParser struct {
+	parser Parser
+}
+
+func (ap *allowEmptyParser) Parse(s string) error {
+	return ap.ParseLookup(s, s != "")
+}
+
+func (ap *allowEmptyParser) ParseLookup(s string, exists bool) error {
+	if exists && s == "" {
+		if e, ok := ap.parser.(emptier); ok {
+			e.empty()
+		}
+		return nil
+	}
+	return parse(ap.parser, s, exists)
+}
+
 type stringParser struct {
 	required    bool
 	destination *string
 }
 
+func (sp *stringParser) empty() {
+	*sp.destination = ""
+}
+
 func (sp *stringParser) Parse(s string) error {
 	if sp.required && s == "" {
-		return errors.New("missing")
+		return ErrMissing
 	} else if s == "" {
 		return nil
 	}
@@ -115,9 +233,13 @@ type secretParser struct {
 	destination **conceal.Text
 }
 
+func (sp *secretParser) empty() {
+	*sp.destination = conceal.New("")
+}
+
 func (sp *secretParser) Parse(s string) error {
 	if sp.required && s == "" {
-		return errors.New("missing")
+		return ErrMissing
 	} else if s == "" {
 		return nil
 	}
@@ -142,16 +264,20 @@ type intParser struct {
 	destination *int
 }
 
+func (ip *intParser) empty() {
+	*ip.destination = 0
+}
+
 func (ip *intParser) Parse(s string) error {
 	if ip.required && s == "" {
-		return errors.New("missing")
+		return ErrMissing
 	} else if s == "" {
 		return nil
 	}
 
-	i, err := strconv.Atoi(s)
+	i, err := convertInt(s)
 	if err != nil {
-		return fmt.Errorf("unable to parse %q as int: %w", s, err)
+		return err
 	}
 	*ip.destination = i
 	return nil
@@ -183,16 +309,20 @@ type floatParser struct {
 	destination *float64
 }
 
+func (fp *floatParser) empty() {
+	*fp.destination = 0
+}
+
 func (fp *floatParser) Parse(s string) error {
 	if fp.required && s == "" {
-		return errors.New("missing")
+		return ErrMissing
 	} else if s == "" {
 		return nil
 	}
 
-	f, err := strconv.ParseFloat(s, 64)
+	f, err := convertFloat(s)
 	if err != nil {
-		return fmt.Errorf("unable to parse %q as float: %w", s, err)
+		return err
 	}
 	*fp.destination = f
 	return nil
@@ -224,9 +354,13 @@ type boolParser struct {
 	destination *bool
 }
 
+func (bp *boolParser) empty() {
+	*bp.destination = false
+}
+
 func (bp *boolParser) Parse(s string) error {
 	if bp.required && s == "" {
-		return errors.New("missing")
+		return ErrMissing
 	} else if s == "" {
 		return nil
 	}
@@ -260,6 +394,110 @@ func BoolOr(b *bool, alt bool) Parser {
 	}
 }
 
+type durationParser struct {
+	required    bool
+	destination *time.Duration
+}
+
+func (dp *durationParser) empty() {
+	*dp.destination = 0
+}
+
+func (dp *durationParser) Parse(s string) error {
+	if dp.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("unable to parse %q as duration: %w", s, err)
+	}
+	*dp.destination = d
+	return nil
+}
+
+// Duration is used to extract an environment variable into a Go
+// time.Duration, using the format accepted by time.ParseDuration. If required
+// is true, then an error is returned if the environment variable is not set or
+// is empty.
+func Duration(d *time.Duration, required bool) Parser {
+	return &durationParser{
+		required:    required,
+		destination: d,
+	}
+}
+
+// DurationOr is used to extract an environment variable into a Go
+// time.Duration, using the format accepted by time.ParseDuration. If the
+// environment variable is not set or is empty, then the alt value is used
+// instead.
+func DurationOr(d *time.Duration, alt time.Duration) Parser {
+	*d = alt
+	return &durationParser{
+		required:    false,
+		destination: d,
+	}
+}
+
+type timeParser struct {
+	required    bool
+	layout      string
+	destination *time.Time
+}
+
+func (tp *timeParser) empty() {
+	*tp.destination = time.Time{}
+}
+
+func (tp *timeParser) Parse(s string) error {
+	if tp.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	t, err := time.Parse(tp.layout, s)
+	if err != nil {
+		return fmt.Errorf("unable to parse %q as time: %w", s, err)
+	}
+	*tp.destination = t
+	return nil
+}
+
+func timeLayout(layout string) string {
+	if layout == "" {
+		return time.RFC3339
+	}
+	return layout
+}
+
+// Time is used to extract an environment variable into a Go time.Time, using
+// the given layout as accepted by time.Parse. If layout is empty, then
+// time.RFC3339 is used. If required is true, then an error is returned if the
+// environment variable is not set or is empty.
+func Time(t *time.Time, layout string, required bool) Parser {
+	return &timeParser{
+		required:    required,
+		layout:      timeLayout(layout),
+		destination: t,
+	}
+}
+
+// TimeOr is used to extract an environment variable into a Go time.Time,
+// using the given layout as accepted by time.Parse. If layout is empty, then
+// time.RFC3339 is used. If the environment variable is not set or is empty,
+// then the alt value is used instead.
+func TimeOr(t *time.Time, layout string, alt time.Time) Parser {
+	*t = alt
+	return &timeParser{
+		required:    false,
+		layout:      timeLayout(layout),
+		destination: t,
+	}
+}
+
 // Environment is something that implements Getenv().
 //
 // Most use cases can simply make use of the OS implementation which is backed
@@ -268,6 +506,26 @@ type Environment interface {
 	Getenv(string) string
 }
 
+// A Lookuper is an Environment that can distinguish a variable that is not set
+// from one that is set to the empty string, mirroring os.LookupEnv.
+//
+// The OS, File, Map, and Chain implementations of Environment are Lookupers.
+type Lookuper interface {
+	Environment
+	Lookup(string) (string, bool)
+}
+
+// Lookup returns the value of the variable name in environment, and whether
+// the variable is set. If environment is not a Lookuper, the variable is
+// considered to be set only if its value is not empty.
+func Lookup(environment Environment, name string) (string, bool) {
+	if l, ok := environment.(Lookuper); ok {
+		return l.Lookup(name)
+	}
+	value := environment.Getenv(name)
+	return value, value != ""
+}
+
 type osEnv struct {
 	// defer to the os package
 }
@@ -276,42 +534,118 @@ func (e *osEnv) Getenv(name string) string {
 	return os.Getenv(name)
 }
 
+func (e *osEnv) Lookup(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+func (e *osEnv) String() string {
+	return "os"
+}
+
 // OS is an implementation of Environment that uses the standard library os
 // package to retrieve actual environment variables.
 var OS Environment = new(osEnv)
 
-// File is an implementation of Environment that reads environment variables
-// from a file.
+// A Loader is an Environment whose variables must first be loaded from some
+// external source. Parse calls Load before parsing any variables so that any
+// failure to load the Environment is reported.
+type Loader interface {
+	Environment
+	Load() error
+}
+
+// File returns a FileEnvironment that reads environment variables from the
+// .env file at filename.
 //
 // e.g. /etc/os-release
-func File(filename string) Environment {
-	return &fileEnv{filename: filename}
+func File(filename string) *FileEnvironment {
+	return &FileEnvironment{filename: filename}
 }
 
-type fileEnv struct {
+// OptionalFile returns a FileEnvironment like File, except that if the file
+// does not exist it is treated as an empty file rather than as an error.
+func OptionalFile(filename string) *FileEnvironment {
+	return &FileEnvironment{filename: filename, optional: true}
+}
+
+// FileEnvironment is an implementation of Environment that reads environment
+// variables from a .env file.
+//
+// The file is interpreted using the .env file format supported by docker
+// compose and the dotenv libraries, which supports quoted values, comments,
+// the export prefix, and variable interpolation. The file is read once, on
+// first use, and its values are kept in memory.
+type FileEnvironment struct {
 	filename string
+	optional bool
+
+	lock   sync.Mutex
+	loaded bool
+	values map[string]string
+	err    error
+}
+
+// Load reads and parses the file, if it has not already been loaded. Any
+// error opening, reading, or parsing the file is returned, and is returned
+// again by subsequent calls to Load.
+func (e *FileEnvironment) Load() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.loaded {
+		e.values, e.err = e.read()
+		e.loaded = true
+	}
+	return e.err
 }
 
-func (e *fileEnv) Getenv(key string) string {
-	f, err := os.Open(e.filename)
+// Reload reads and parses the file again, replacing any previously loaded
+// values. If the file cannot be read or is malformed, the previously loaded
+// values are kept and the error is returned.
+func (e *FileEnvironment) Reload() error {
+	values, err := e.read()
 	if err != nil {
-		return ""
+		return err
 	}
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	e.lock.Lock()
+	defer e.lock.Unlock()
 
-		idx := strings.Index(line, "=")
-		if idx < 1 || idx >= len(line)-1 {
-			continue
-		}
-		if line[0:idx] == key {
-			return line[idx+1:]
-		}
+	e.values, e.err = values, nil
+	e.loaded = true
+	return nil
+}
+
+func (e *FileEnvironment) read() (map[string]string, error) {
+	values, err := readDotenv(e.filename)
+	if e.optional && errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]string), nil
 	}
+	return values, err
+}
+
+// Getenv returns the value of the variable key in the file. If the file
+// cannot be read or is malformed, every variable is treated as not set; use
+// Load to learn why.
+func (e *FileEnvironment) Getenv(key string) string {
+	value, _ := e.Lookup(key)
+	return value
+}
 
-	return ""
+// Lookup returns the value of the variable key in the file, and whether the
+// variable is set in the file.
+func (e *FileEnvironment) Lookup(key string) (string, bool) {
+	_ = e.Load()
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	value, exists := e.values[key]
+	return value, exists
+}
+
+func (e *FileEnvironment) String() string {
+	return "file(" + e.filename + ")"
 }
 
 // Map is an implementation of Environment that uses a given map[string]string
@@ -333,3 +667,12 @@ type mapEnv struct {
 func (m *mapEnv) Getenv(key string) string {
 	return m.m[key]
 }
+
+func (m *mapEnv) Lookup(key string) (string, bool) {
+	value, exists := m.m[key]
+	return value, exists
+}
+
+func (m *mapEnv) String() string {
+	return "map"
+}
@@ -4,10 +4,14 @@
 package env
 
 import (
+	"errors"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/shoenig/go-conceal"
 	"github.com/shoenig/test/must"
@@ -190,6 +194,152 @@ func Test_Parse_fail(t *testing.T) {
 	}
 }
 
+func Test_Parse_all_errors(t *testing.T) {
+	var (
+		foo string
+		bar int
+		baz float64
+		b1  bool
+	)
+
+	err := ParseMap(map[string]string{
+		"BAR": "abc",
+		"B1":  "true",
+	}, Schema{
+		"FOO": String(&foo, true),
+		"BAR": Int(&bar, true),
+		"BAZ": Float(&baz, true),
+		"B1":  Bool(&b1, true),
+	})
+	must.Error(t, err)
+	must.True(t, b1)
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.Len(t, 3, errs)
+	must.Eq(t, "BAR", errs[0].Variable)
+	must.Eq(t, "BAZ", errs[1].Variable)
+	must.Eq(t, "FOO", errs[2].Variable)
+
+	must.ErrorIs(t, err, ErrMissing)
+	must.ErrorIs(t, errs[1], ErrMissing)
+	must.ErrorIs(t, errs[0], strconv.ErrSyntax)
+	must.EqError(t, err, `failed to parse "{BAR}": unable to parse "abc" as int: strconv.Atoi: parsing "abc": invalid syntax; `+
+		`failed to parse "{BAZ}": missing; `+
+		`failed to parse "{FOO}": missing`)
+}
+
+func Test_Parse_AllowEmpty(t *testing.T) {
+	environment := Map(map[string]string{
+		"S1": "",
+		"S3": "value",
+		"I1": "",
+		"P1": "",
+	})
+
+	var (
+		s1, s2, s3 string
+		i1         int
+		p1         *conceal.Text
+	)
+
+	err := Parse(environment, Schema{
+		"S1": AllowEmpty(StringOr(&s1, "alt")),
+		"S2": AllowEmpty(StringOr(&s2, "alt")),
+		"S3": AllowEmpty(StringOr(&s3, "alt")),
+		"I1": AllowEmpty(IntOr(&i1, 42)),
+		"P1": AllowEmpty(Secret(&p1, true)),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "", s1)
+	must.Eq(t, "alt", s2)
+	must.Eq(t, "value", s3)
+	must.Eq(t, 0, i1)
+	must.Eq(t, "", p1.Unveil())
+
+	var s4 string
+	err = Parse(environment, Schema{
+		"S4": AllowEmpty(String(&s4, true)),
+	})
+	must.ErrorIs(t, err, ErrMissing)
+}
+
+func Test_Lookup(t *testing.T) {
+	t.Setenv("SET", "value")
+	t.Setenv("EMPTY", "")
+
+	temp := filepath.Join(t.TempDir(), "test.env")
+	must.NoError(t, os.WriteFile(temp, []byte("SET=value\nEMPTY=\n"), 0644))
+
+	environments := map[string]Environment{
+		"os":    OS,
+		"file":  File(temp),
+		"map":   Map(map[string]string{"SET": "value", "EMPTY": ""}),
+		"chain": Chain(Map(map[string]string{"EMPTY": ""}), Map(map[string]string{"SET": "value"})),
+	}
+
+	for name, environment := range environments {
+		t.Run(name, func(t *testing.T) {
+			value, exists := Lookup(environment, "SET")
+			must.Eq(t, "value", value)
+			must.True(t, exists)
+
+			value, exists = Lookup(environment, "EMPTY")
+			must.Eq(t, "", value)
+			must.True(t, exists)
+
+			value, exists = Lookup(environment, "UNSET_VARIABLE")
+			must.Eq(t, "", value)
+			must.False(t, exists)
+		})
+	}
+}
+
+func Test_Parse_time(t *testing.T) {
+	environment := Map(map[string]string{
+		"TIMEOUT": "1m30s",
+		"START":   "2024-05-06T07:08:09Z",
+		"DATE":    "2024-05-06",
+		"BAD":     "90",
+	})
+
+	var (
+		timeout  time.Duration
+		ttl      time.Duration
+		start    time.Time
+		date     time.Time
+		deadline time.Time
+	)
+
+	fallback := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := Parse(environment, Schema{
+		"TIMEOUT":  Duration(&timeout, true),
+		"TTL":      DurationOr(&ttl, 5*time.Second),
+		"START":    Time(&start, "", true),
+		"DATE":     Time(&date, time.DateOnly, true),
+		"DEADLINE": TimeOr(&deadline, "", fallback),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 90*time.Second, timeout)
+	must.Eq(t, 5*time.Second, ttl)
+	must.Eq(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), start)
+	must.Eq(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), date)
+	must.Eq(t, fallback, deadline)
+
+	var bad time.Duration
+	err = Parse(environment, Schema{
+		"BAD": Duration(&bad, true),
+	})
+	must.ErrorContains(t, err, `unable to parse "90" as duration`)
+
+	var missing time.Time
+	err = Parse(environment, Schema{
+		"MISSING": Time(&missing, "", true),
+	})
+	must.ErrorIs(t, err, ErrMissing)
+}
+
 func Test_ParseOS(t *testing.T) {
 	var xTerm string
 
@@ -230,6 +380,43 @@ THREE=three
 	must.Eq(t, "", missing)
 }
 
+func Test_File_Load(t *testing.T) {
+	temp := filepath.Join(t.TempDir(), "test.env")
+	must.NoError(t, os.WriteFile(temp, []byte("ONE=1\n"), 0644))
+
+	f := File(temp)
+	must.NoError(t, f.Load())
+	must.Eq(t, "1", f.Getenv("ONE"))
+
+	// values are kept in memory until reloaded
+	must.NoError(t, os.WriteFile(temp, []byte("ONE=one\nTWO=2\n"), 0644))
+	must.Eq(t, "1", f.Getenv("ONE"))
+	must.Eq(t, "", f.Getenv("TWO"))
+
+	must.NoError(t, f.Reload())
+	must.Eq(t, "one", f.Getenv("ONE"))
+	must.Eq(t, "2", f.Getenv("TWO"))
+
+	// a failed reload keeps the previous values
+	must.NoError(t, os.WriteFile(temp, []byte("BROKEN\n"), 0644))
+	must.Error(t, f.Reload())
+	must.Eq(t, "one", f.Getenv("ONE"))
+}
+
+func Test_File_missing(t *testing.T) {
+	temp := filepath.Join(t.TempDir(), "missing.env")
+
+	f := File(temp)
+	must.ErrorIs(t, f.Load(), fs.ErrNotExist)
+	must.Eq(t, "", f.Getenv("ONE"))
+
+	var one string
+	err := ParseFile(temp, Schema{
+		"ONE": String(&one, false),
+	})
+	must.ErrorIs(t, err, fs.ErrNotExist)
+}
+
 func Test_ParseFile(t *testing.T) {
 	temp := filepath.Join(t.TempDir(), "test.env")
 	file, err := os.OpenFile(temp, os.O_CREATE|os.O_WRONLY, 0644)
@@ -0,0 +1,106 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"github.com/shoenig/extractors/internal/ids"
+)
+
+type idParser[T any] struct {
+	required    bool
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (ip *idParser[T]) empty() {
+	var zero T
+	*ip.destination = zero
+}
+
+func (ip *idParser[T]) Parse(s string) error {
+	if ip.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	id, err := ip.convert(s)
+	if err != nil {
+		return err
+	}
+	*ip.destination = id
+	return nil
+}
+
+// UUID is used to extract an environment variable in the canonical RFC 9562
+// form into u, which may be any type of [16]byte such as uuid.UUID. If
+// versions are given, then the UUID must be one of those versions. If required
+// is true, then an error is returned if the environment variable is not set or
+// is empty.
+func UUID[T ~[16]byte](u *T, required bool, versions ...int) Parser {
+	return &idParser[T]{
+		required:    required,
+		convert:     ids.UUIDFunc[T](versions...),
+		destination: u,
+	}
+}
+
+// UUIDOr is used to extract an environment variable in the canonical RFC 9562
+// form into u, which may be any type of [16]byte such as uuid.UUID. If the
+// environment variable is not set or is empty, then the alt value is used
+// instead.
+func UUIDOr[T ~[16]byte](u *T, alt T, versions ...int) Parser {
+	*u = alt
+	return &idParser[T]{
+		required:    false,
+		convert:     ids.UUIDFunc[T](versions...),
+		destination: u,
+	}
+}
+
+// ULID is used to extract an environment variable into u, which may be any
+// type of [16]byte such as ulid.ULID. If required is true, then an error is
+// returned if the environment variable is not set or is empty.
+func ULID[T ~[16]byte](u *T, required bool) Parser {
+	return &idParser[T]{
+		required:    required,
+		convert:     ids.ParseULID[T],
+		destination: u,
+	}
+}
+
+// ULIDOr is used to extract an environment variable into u, which may be any
+// type of [16]byte such as ulid.ULID. If the environment variable is not set
+// or is empty, then the alt value is used instead.
+func ULIDOr[T ~[16]byte](u *T, alt T) Parser {
+	*u = alt
+	return &idParser[T]{
+		required:    false,
+		convert:     ids.ParseULID[T],
+		destination: u,
+	}
+}
+
+// KSUID is used to extract an environment variable into k, which may be any
+// type of [20]byte such as ksuid.KSUID. If required is true, then an error is
+// returned if the environment variable is not set or is empty.
+func KSUID[T ~[20]byte](k *T, required bool) Parser {
+	return &idParser[T]{
+		required:    required,
+		convert:     ids.ParseKSUID[T],
+		destination: k,
+	}
+}
+
+// KSUIDOr is used to extract an environment variable into k, which may be any
+// type of [20]byte such as ksuid.KSUID. If the environment variable is not set
+// or is empty, then the alt value is used instead.
+func KSUIDOr[T ~[20]byte](k *T, alt T) Parser {
+	*k = alt
+	return &idParser[T]{
+		required:    false,
+		convert:     ids.ParseKSUID[T],
+		destination: k,
+	}
+}
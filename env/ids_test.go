@@ -0,0 +1,68 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type nodeID [16]byte
+
+func Test_Parse_ids(t *testing.T) {
+	environment := Map(map[string]string{
+		"NODE_ID":  "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"BUILD_ID": "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"TRACE_ID": "0ujtsYcgvSTl8PAuAdqWYSMnLOv",
+	})
+
+	var (
+		node     nodeID
+		build    [16]byte
+		trace    [20]byte
+		cluster  nodeID
+		release  [16]byte
+		span     [20]byte
+		fallback = nodeID{1}
+	)
+
+	err := Parse(environment, Schema{
+		"NODE_ID":    UUID(&node, true, 4),
+		"BUILD_ID":   ULID(&build, true),
+		"TRACE_ID":   KSUID(&trace, true),
+		"CLUSTER_ID": UUIDOr(&cluster, fallback),
+		"RELEASE_ID": ULIDOr(&release, [16]byte{2}),
+		"SPAN_ID":    KSUIDOr(&span, [20]byte{3}),
+	})
+	must.NoError(t, err)
+	must.EqOp(t, 0xf4, node[0])
+	must.EqOp(t, 0x01, build[0])
+	must.EqOp(t, 0x06, trace[0])
+	must.Eq(t, fallback, cluster)
+	must.Eq(t, [16]byte{2}, release)
+	must.Eq(t, [20]byte{3}, span)
+}
+
+func Test_Parse_ids_fail(t *testing.T) {
+	var node nodeID
+
+	err := ParseMap(map[string]string{"X": "nope"}, Schema{
+		"X": UUID(&node, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.EqError(t, err, `failed to parse "{X}": unable to parse "nope" as UUID: invalid syntax`)
+
+	err = ParseMap(nil, Schema{
+		"X": UUID(&node, true),
+	})
+	must.ErrorIs(t, err, ErrMissing)
+
+	var trace [20]byte
+	err = ParseMap(map[string]string{"X": "aWgEPTl1tmebfsQzFP4bxwgy80W"}, Schema{
+		"X": KSUID(&trace, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+}
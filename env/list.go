@@ -0,0 +1,236 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A ListOption configures how the value of an environment variable is split
+// into elements by the Strings, Ints, and Floats parsers.
+//
+// By default elements are separated by a comma, leading and trailing whitespace
+// is trimmed from each element, and an empty element is an error.
+type ListOption func(*listOptions)
+
+type emptyElements int
+
+const (
+	rejectEmpty emptyElements = iota
+	skipEmpty
+	keepEmpty
+)
+
+type listOptions struct {
+	separator string
+	trim      bool
+	empty     emptyElements
+	min       int
+	max       int
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	options := listOptions{
+		separator: ",",
+		trim:      true,
+		empty:     rejectEmpty,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// Separator sets the separator between elements, instead of a comma.
+func Separator(sep string) ListOption {
+	return func(o *listOptions) {
+		o.separator = sep
+	}
+}
+
+// NoTrim disables trimming leading and trailing whitespace from elements.
+func NoTrim() ListOption {
+	return func(o *listOptions) {
+		o.trim = false
+	}
+}
+
+// SkipEmpty causes empty elements to be ignored, rather than be an error.
+func SkipEmpty() ListOption {
+	return func(o *listOptions) {
+		o.empty = skipEmpty
+	}
+}
+
+// KeepEmpty causes empty elements to be parsed like any other element, rather
+// than be an error. Only useful with Strings.
+func KeepEmpty() ListOption {
+	return func(o *listOptions) {
+		o.empty = keepEmpty
+	}
+}
+
+// MinLen causes an error if there are fewer than n elements.
+func MinLen(n int) ListOption {
+	return func(o *listOptions) {
+		o.min = n
+	}
+}
+
+// MaxLen causes an error if there are more than n elements.
+func MaxLen(n int) ListOption {
+	return func(o *listOptions) {
+		o.max = n
+	}
+}
+
+type listParser[T any] struct {
+	required    bool
+	destination *[]T
+	convert     func(string) (T, error)
+	options     listOptions
+}
+
+func (lp *listParser[T]) empty() {
+	*lp.destination = nil
+}
+
+func (lp *listParser[T]) Parse(s string) error {
+	if lp.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	elements := strings.Split(s, lp.options.separator)
+	result := make([]T, 0, len(elements))
+	for i, element := range elements {
+		if lp.options.trim {
+			element = strings.TrimSpace(element)
+		}
+
+		if element == "" {
+			switch lp.options.empty {
+			case skipEmpty:
+				continue
+			case rejectEmpty:
+				return fmt.Errorf("element %d is empty", i)
+			case keepEmpty:
+			}
+		}
+
+		value, err := lp.convert(element)
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, value)
+	}
+
+	switch {
+	case len(result) < lp.options.min:
+		return fmt.Errorf("expected at least %d elements, got %d", lp.options.min, len(result))
+	case lp.options.max > 0 && len(result) > lp.options.max:
+		return fmt.Errorf("expected at most %d elements, got %d", lp.options.max, len(result))
+	}
+
+	*lp.destination = result
+	return nil
+}
+
+func convertString(s string) (string, error) {
+	return s, nil
+}
+
+func convertInt(s string) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as int: %w", s, err)
+	}
+	return i, nil
+}
+
+func convertFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as float: %w", s, err)
+	}
+	return f, nil
+}
+
+// Strings is used to extract an environment variable containing a list of
+// elements into a Go []string. If required is true, then an error is returned
+// if the environment variable is not set or is empty.
+func Strings(s *[]string, required bool, opts ...ListOption) Parser {
+	return &listParser[string]{
+		required:    required,
+		destination: s,
+		convert:     convertString,
+		options:     newListOptions(opts),
+	}
+}
+
+// StringsOr is used to extract an environment variable containing a list of
+// elements into a Go []string. If the environment variable is not set or is
+// empty, then the alt value is used instead.
+func StringsOr(s *[]string, alt []string, opts ...ListOption) Parser {
+	*s = alt
+	return &listParser[string]{
+		required:    false,
+		destination: s,
+		convert:     convertString,
+		options:     newListOptions(opts),
+	}
+}
+
+// Ints is used to extract an environment variable containing a list of
+// elements into a Go []int. If required is true, then an error is returned
+// if the environment variable is not set or is empty.
+func Ints(i *[]int, required bool, opts ...ListOption) Parser {
+	return &listParser[int]{
+		required:    required,
+		destination: i,
+		convert:     convertInt,
+		options:     newListOptions(opts),
+	}
+}
+
+// IntsOr is used to extract an environment variable containing a list of
+// elements into a Go []int. If the environment variable is not set or is
+// empty, then the alt value is used instead.
+func IntsOr(i *[]int, alt []int, opts ...ListOption) Parser {
+	*i = alt
+	return &listParser[int]{
+		required:    false,
+		destination: i,
+		convert:     convertInt,
+		options:     newListOptions(opts),
+	}
+}
+
+// Floats is used to extract an environment variable containing a list of
+// elements into a Go []float64. If required is true, then an error is
+// returned if the environment variable is not set or is empty.
+func Floats(f *[]float64, required bool, opts ...ListOption) Parser {
+	return &listParser[float64]{
+		required:    required,
+		destination: f,
+		convert:     convertFloat,
+		options:     newListOptions(opts),
+	}
+}
+
+// FloatsOr is used to extract an environment variable containing a list of
+// elements into a Go []float64. If the environment variable is not set or is
+// empty, then the alt value is used instead.
+func FloatsOr(f *[]float64, alt []float64, opts ...ListOption) Parser {
+	*f = alt
+	return &listParser[float64]{
+		required:    false,
+		destination: f,
+		convert:     convertFloat,
+		options:     newListOptions(opts),
+	}
+}
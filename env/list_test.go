@@ -0,0 +1,109 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_lists(t *testing.T) {
+	environment := Map(map[string]string{
+		"ORIGINS": " a.example.com, b.example.com ,c.example.com",
+		"PORTS":   "80;443;8080",
+		"RATIOS":  "0.5,1.5",
+		"FLAGS":   "a,,b,",
+		"KEEP":    "a,,b",
+		"RAW":     " a | b ",
+	})
+
+	var (
+		origins []string
+		ports   []int
+		ratios  []float64
+		flags   []string
+		keep    []string
+		raw     []string
+		missing []int
+	)
+
+	err := Parse(environment, Schema{
+		"ORIGINS": Strings(&origins, true),
+		"PORTS":   Ints(&ports, true, Separator(";")),
+		"RATIOS":  Floats(&ratios, true),
+		"FLAGS":   Strings(&flags, true, SkipEmpty()),
+		"KEEP":    Strings(&keep, true, KeepEmpty()),
+		"RAW":     Strings(&raw, true, Separator("|"), NoTrim()),
+		"MISSING": IntsOr(&missing, []int{1, 2}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []string{"a.example.com", "b.example.com", "c.example.com"}, origins)
+	must.Eq(t, []int{80, 443, 8080}, ports)
+	must.Eq(t, []float64{0.5, 1.5}, ratios)
+	must.Eq(t, []string{"a", "b"}, flags)
+	must.Eq(t, []string{"a", "", "b"}, keep)
+	must.Eq(t, []string{" a ", " b "}, raw)
+	must.Eq(t, []int{1, 2}, missing)
+}
+
+func Test_Parse_lists_fail(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  string
+		parser func(*[]int) Parser
+		exp    string
+	}{
+		{
+			name:   "missing",
+			value:  "",
+			parser: func(i *[]int) Parser { return Ints(i, true) },
+			exp:    `failed to parse "{X}": missing`,
+		},
+		{
+			name:   "malformed",
+			value:  "1,2,x",
+			parser: func(i *[]int) Parser { return Ints(i, true) },
+			exp:    `failed to parse "{X}": element 2: unable to parse "x" as int: strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			name:   "empty",
+			value:  "1,,2",
+			parser: func(i *[]int) Parser { return Ints(i, true) },
+			exp:    `failed to parse "{X}": element 1 is empty`,
+		},
+		{
+			name:   "too few",
+			value:  "1,2",
+			parser: func(i *[]int) Parser { return Ints(i, true, MinLen(3)) },
+			exp:    `failed to parse "{X}": expected at least 3 elements, got 2`,
+		},
+		{
+			name:   "too many",
+			value:  "1,2,3",
+			parser: func(i *[]int) Parser { return Ints(i, true, MaxLen(2)) },
+			exp:    `failed to parse "{X}": expected at most 2 elements, got 3`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result []int
+			err := ParseMap(map[string]string{"X": tc.value}, Schema{
+				"X": tc.parser(&result),
+			})
+			must.EqError(t, err, tc.exp)
+			must.Nil(t, result)
+		})
+	}
+}
+
+func Test_Parse_lists_errors_wrap(t *testing.T) {
+	var f []float64
+	err := ParseMap(map[string]string{"F": "1.0,abc"}, Schema{
+		"F": Floats(&f, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A MapOption configures how the value of an environment variable is split
+// into key/value pairs by the StringMap, IntMap, and FloatMap parsers.
+//
+// By default pairs are separated by a comma, keys are separated from values
+// by a colon, and leading and trailing whitespace is trimmed from each key
+// and value.
+//
+//	DEFAULT_LABELS=team:core,tier:gold
+type MapOption func(*mapOptions)
+
+type mapOptions struct {
+	pairSeparator     string
+	keyValueSeparator string
+}
+
+func newMapOptions(opts []MapOption) mapOptions {
+	options := mapOptions{
+		pairSeparator:     ",",
+		keyValueSeparator: ":",
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// PairSeparator sets the separator between key/value pairs, instead of a comma.
+func PairSeparator(sep string) MapOption {
+	return func(o *mapOptions) {
+		o.pairSeparator = sep
+	}
+}
+
+// KeyValueSeparator sets the separator between the key and value of each
+// pair, instead of a colon.
+func KeyValueSeparator(sep string) MapOption {
+	return func(o *mapOptions) {
+		o.keyValueSeparator = sep
+	}
+}
+
+type mapParser[T any] struct {
+	required    bool
+	destination *map[string]T
+	convert     func(string) (T, error)
+	options     mapOptions
+}
+
+func (mp *mapParser[T]) empty() {
+	*mp.destination = nil
+}
+
+func (mp *mapParser[T]) Parse(s string) error {
+	if mp.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	result := make(map[string]T)
+	for _, pair := range strings.Split(s, mp.options.pairSeparator) {
+		k, v, found := strings.Cut(pair, mp.options.keyValueSeparator)
+		if !found {
+			return fmt.Errorf("malformed pair %q: missing separator %q", pair, mp.options.keyValueSeparator)
+		}
+
+		key := strings.TrimSpace(k)
+		if key == "" {
+			return fmt.Errorf("malformed pair %q: missing key", pair)
+		}
+
+		if _, exists := result[key]; exists {
+			return fmt.Errorf("malformed pair %q: duplicate key %q", pair, key)
+		}
+
+		value, err := mp.convert(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("malformed pair %q: %w", pair, err)
+		}
+		result[key] = value
+	}
+
+	*mp.destination = result
+	return nil
+}
+
+// StringMap is used to extract an environment variable containing a list of
+// key/value pairs into a Go map[string]string. If required is true, then an
+// error is returned if the environment variable is not set or is empty.
+func StringMap(m *map[string]string, required bool, opts ...MapOption) Parser {
+	return &mapParser[string]{
+		required:    required,
+		destination: m,
+		convert:     convertString,
+		options:     newMapOptions(opts),
+	}
+}
+
+// StringMapOr is used to extract an environment variable containing a list of
+// key/value pairs into a Go map[string]string. If the environment variable is
+// not set or is empty, then the alt value is used instead.
+func StringMapOr(m *map[string]string, alt map[string]string, opts ...MapOption) Parser {
+	*m = alt
+	return &mapParser[string]{
+		required:    false,
+		destination: m,
+		convert:     convertString,
+		options:     newMapOptions(opts),
+	}
+}
+
+// IntMap is used to extract an environment variable containing a list of
+// key/value pairs into a Go map[string]int. If required is true, then an
+// error is returned if the environment variable is not set or is empty.
+func IntMap(m *map[string]int, required bool, opts ...MapOption) Parser {
+	return &mapParser[int]{
+		required:    required,
+		destination: m,
+		convert:     convertInt,
+		options:     newMapOptions(opts),
+	}
+}
+
+// IntMapOr is used to extract an environment variable containing a list of
+// key/value pairs into a Go map[string]int. If the environment variable is
+// not set or is empty, then the alt value is used instead.
+func IntMapOr(m *map[string]int, alt map[string]int, opts ...MapOption) Parser {
+	*m = alt
+	return &mapParser[int]{
+		required:    false,
+		destination: m,
+		convert:     convertInt,
+		options:     newMapOptions(opts),
+	}
+}
+
+// FloatMap is used to extract an environment variable containing a list of
+// key/value pairs into a Go map[string]float64. If required is true, then an
+// error is returned if the environment variable is not set or is empty.
+func FloatMap(m *map[string]float64, required bool, opts ...MapOption) Parser {
+	return &mapParser[float64]{
+		required:    required,
+		destination: m,
+		convert:     convertFloat,
+		options:     newMapOptions(opts),
+	}
+}
+
+// FloatMapOr is used to extract an environment variable containing a list of
+// key/value pairs into a Go map[string]float64. If the environment variable
+// is not set or is empty, then the alt value is used instead.
+func FloatMapOr(m *map[string]float64, alt map[string]float64, opts ...MapOption) Parser {
+	*m = alt
+	return &mapParser[float64]{
+		required:    false,
+		destination: m,
+		convert:     convertFloat,
+		options:     newMapOptions(opts),
+	}
+}
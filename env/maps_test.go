@@ -0,0 +1,82 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_maps(t *testing.T) {
+	environment := Map(map[string]string{
+		"LABELS":  "team:core, tier : gold",
+		"LIMITS":  "acme=10;globex=20",
+		"WEIGHTS": "a:0.5,b:1.5",
+	})
+
+	var (
+		labels  map[string]string
+		limits  map[string]int
+		weights map[string]float64
+		missing map[string]string
+	)
+
+	err := Parse(environment, Schema{
+		"LABELS":  StringMap(&labels, true),
+		"LIMITS":  IntMap(&limits, true, PairSeparator(";"), KeyValueSeparator("=")),
+		"WEIGHTS": FloatMap(&weights, true),
+		"MISSING": StringMapOr(&missing, map[string]string{"x": "y"}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"team": "core", "tier": "gold"}, labels)
+	must.Eq(t, map[string]int{"acme": 10, "globex": 20}, limits)
+	must.Eq(t, map[string]float64{"a": 0.5, "b": 1.5}, weights)
+	must.Eq(t, map[string]string{"x": "y"}, missing)
+}
+
+func Test_Parse_maps_fail(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		exp   string
+	}{
+		{
+			name:  "missing",
+			value: "",
+			exp:   `failed to parse "{X}": missing`,
+		},
+		{
+			name:  "no separator",
+			value: "a:1,b",
+			exp:   `failed to parse "{X}": malformed pair "b": missing separator ":"`,
+		},
+		{
+			name:  "no key",
+			value: "a:1, :2",
+			exp:   `failed to parse "{X}": malformed pair " :2": missing key`,
+		},
+		{
+			name:  "duplicate",
+			value: "a:1,b:2,a:3",
+			exp:   `failed to parse "{X}": malformed pair "a:3": duplicate key "a"`,
+		},
+		{
+			name:  "bad value",
+			value: "a:1,b:two",
+			exp:   `failed to parse "{X}": malformed pair "b:two": unable to parse "two" as int: strconv.Atoi: parsing "two": invalid syntax`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result map[string]int
+			err := ParseMap(map[string]string{"X": tc.value}, Schema{
+				"X": IntMap(&result, true),
+			})
+			must.EqError(t, err, tc.exp)
+			must.Nil(t, result)
+		})
+	}
+}
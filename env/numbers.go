@@ -0,0 +1,125 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"fmt"
+
+	"github.com/shoenig/extractors/internal/numbers"
+)
+
+type numberParser[T numbers.Number] struct {
+	required    bool
+	bounded     bool
+	lo, hi      T
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (np *numberParser[T]) empty() {
+	*np.destination = 0
+}
+
+func (np *numberParser[T]) Parse(s string) error {
+	if np.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	n, err := np.convert(s)
+	if err != nil {
+		return fmt.Errorf("unable to parse %q as %T: %w", s, n, err)
+	}
+
+	if np.bounded {
+		if err = numbers.CheckRange(n, np.lo, np.hi); err != nil {
+			return err
+		}
+	}
+
+	*np.destination = n
+	return nil
+}
+
+// Integer is used to extract an environment variable into any Go integer
+// type. If the value does not fit in the type, an error wrapping
+// strconv.ErrRange is returned. If required is true, then an error is returned
+// if the environment variable is not set or is empty.
+func Integer[T numbers.Integer](i *T, required bool) Parser {
+	return &numberParser[T]{
+		required:    required,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// IntegerOr is used to extract an environment variable into any Go integer
+// type. If the environment variable is not set or is empty, then the alt
+// value is used instead.
+func IntegerOr[T numbers.Integer](i *T, alt T) Parser {
+	*i = alt
+	return &numberParser[T]{
+		required:    false,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// IntegerBetween is used to extract an environment variable into any Go
+// integer type. If the value is not within the inclusive range of lo to hi,
+// an error wrapping strconv.ErrRange is returned. If required is true, then an
+// error is returned if the environment variable is not set or is empty.
+//
+//	env.IntegerBetween(&port, 1, 65535, true)
+func IntegerBetween[T numbers.Integer](i *T, lo, hi T, required bool) Parser {
+	return &numberParser[T]{
+		required:    required,
+		bounded:     true,
+		lo:          lo,
+		hi:          hi,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// Floating is used to extract an environment variable into any Go floating
+// point type. If the value does not fit in the type, an error wrapping
+// strconv.ErrRange is returned. If required is true, then an error is returned
+// if the environment variable is not set or is empty.
+func Floating[T numbers.Float](f *T, required bool) Parser {
+	return &numberParser[T]{
+		required:    required,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
+
+// FloatingOr is used to extract an environment variable into any Go floating
+// point type. If the environment variable is not set or is empty, then the
+// alt value is used instead.
+func FloatingOr[T numbers.Float](f *T, alt T) Parser {
+	*f = alt
+	return &numberParser[T]{
+		required:    false,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
+
+// FloatingBetween is used to extract an environment variable into any Go
+// floating point type. If the value is not within the inclusive range of lo
+// to hi, an error wrapping strconv.ErrRange is returned. If required is true,
+// then an error is returned if the environment variable is not set or is
+// empty.
+func FloatingBetween[T numbers.Float](f *T, lo, hi T, required bool) Parser {
+	return &numberParser[T]{
+		required:    required,
+		bounded:     true,
+		lo:          lo,
+		hi:          hi,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_numbers(t *testing.T) {
+	type port uint16
+
+	environment := Map(map[string]string{
+		"I8":    "-100",
+		"U64":   "18446744073709551615",
+		"PORT":  "8080",
+		"F32":   "2.5",
+		"RATIO": "0.25",
+	})
+
+	var (
+		i8    int8
+		u64   uint64
+		p     port
+		f32   float32
+		ratio float64
+		i16   int16
+		f     float32
+	)
+
+	err := Parse(environment, Schema{
+		"I8":    Integer(&i8, true),
+		"U64":   Integer(&u64, true),
+		"PORT":  IntegerBetween(&p, 1, 65535, true),
+		"F32":   Floating(&f32, true),
+		"RATIO": FloatingBetween(&ratio, 0, 1, true),
+		"I16":   IntegerOr(&i16, 42),
+		"F":     FloatingOr(&f, float32(1.5)),
+	})
+	must.NoError(t, err)
+	must.Eq(t, -100, i8)
+	must.Eq(t, uint64(18446744073709551615), u64)
+	must.Eq(t, 8080, p)
+	must.Eq(t, 2.5, f32)
+	must.Eq(t, 0.25, ratio)
+	must.Eq(t, 42, i16)
+	must.Eq(t, 1.5, f)
+}
+
+func Test_Parse_numbers_fail(t *testing.T) {
+	environment := Map(map[string]string{
+		"OVERFLOW": "300",
+		"PORT":     "0",
+		"RATIO":    "1.5",
+		"BAD":      "abc",
+	})
+
+	var i8 int8
+	err := Parse(environment, Schema{
+		"OVERFLOW": Integer(&i8, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+	must.EqError(t, err, `failed to parse "{OVERFLOW}": unable to parse "300" as int8: strconv.ParseInt: parsing "300": value out of range`)
+
+	var port uint16
+	err = Parse(environment, Schema{
+		"PORT": IntegerBetween(&port, 1, 65535, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+	must.EqError(t, err, `failed to parse "{PORT}": value out of range: 0 is not between 1 and 65535`)
+
+	var ratio float64
+	err = Parse(environment, Schema{
+		"RATIO": FloatingBetween(&ratio, 0, 1, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	err = ParseMap(map[string]string{"RATIO": "NaN"}, Schema{
+		"RATIO": FloatingBetween(&ratio, 0, 1, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	var u uint
+	err = Parse(environment, Schema{
+		"BAD": Integer(&u, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = Parse(environment, Schema{
+		"MISSING": Integer(&u, true),
+	})
+	must.ErrorIs(t, err, ErrMissing)
+}
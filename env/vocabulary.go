@@ -0,0 +1,67 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"fmt"
+
+	"github.com/shoenig/extractors/internal/boolean"
+)
+
+// A Vocabulary is the set of values recognized as true and as false by the
+// BoolWith and BoolWithOr parsers. Values are matched case-insensitively.
+//
+//	vocabulary := env.Vocabulary{
+//	  True:  []string{"on", "yes", "enabled"},
+//	  False: []string{"off", "no", "disabled"},
+//	}
+type Vocabulary = boolean.Vocabulary
+
+type vocabularyParser struct {
+	required    bool
+	vocabulary  Vocabulary
+	destination *bool
+}
+
+func (vp *vocabularyParser) empty() {
+	*vp.destination = false
+}
+
+func (vp *vocabularyParser) Parse(s string) error {
+	if vp.required && s == "" {
+		return ErrMissing
+	} else if s == "" {
+		return nil
+	}
+
+	b, err := vp.vocabulary.Parse(s)
+	if err != nil {
+		return fmt.Errorf("unable to parse %q as bool: %w", s, err)
+	}
+	*vp.destination = b
+	return nil
+}
+
+// BoolWith is used to extract an environment variable into a Go bool,
+// recognizing only the values of vocabulary. If required is true, then an
+// error is returned if the environment variable is not set or is empty.
+func BoolWith(b *bool, vocabulary Vocabulary, required bool) Parser {
+	return &vocabularyParser{
+		required:    required,
+		vocabulary:  vocabulary,
+		destination: b,
+	}
+}
+
+// BoolWithOr is used to extract an environment variable into a Go bool,
+// recognizing only the values of vocabulary. If the environment variable is
+// not set or is empty, then the alt value is used instead.
+func BoolWithOr(b *bool, vocabulary Vocabulary, alt bool) Parser {
+	*b = alt
+	return &vocabularyParser{
+		required:    false,
+		vocabulary:  vocabulary,
+		destination: b,
+	}
+}
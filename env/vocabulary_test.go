@@ -0,0 +1,53 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package env
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_BoolWith(t *testing.T) {
+	vocabulary := Vocabulary{
+		True:  []string{"on", "enabled"},
+		False: []string{"off", "disabled"},
+	}
+
+	environment := Map(map[string]string{
+		"CACHE":   "on",
+		"METRICS": "Disabled",
+	})
+
+	var cache, metrics, tracing bool
+	err := Parse(environment, Schema{
+		"CACHE":   BoolWith(&cache, vocabulary, true),
+		"METRICS": BoolWithOr(&metrics, vocabulary, true),
+		"TRACING": BoolWithOr(&tracing, vocabulary, true),
+	})
+	must.NoError(t, err)
+	must.True(t, cache)
+	must.False(t, metrics)
+	must.True(t, tracing)
+}
+
+func Test_Parse_BoolWith_fail(t *testing.T) {
+	vocabulary := Vocabulary{
+		True:  []string{"on"},
+		False: []string{"off"},
+	}
+
+	var b bool
+	err := ParseMap(map[string]string{"X": "true"}, Schema{
+		"X": BoolWith(&b, vocabulary, true),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.EqError(t, err, `failed to parse "{X}": unable to parse "true" as bool: expected one of "on", "off": invalid syntax`)
+
+	err = ParseMap(nil, Schema{
+		"X": BoolWith(&b, vocabulary, true),
+	})
+	must.ErrorIs(t, err, ErrMissing)
+}
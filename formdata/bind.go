@@ -0,0 +1,255 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shoenig/extractors/internal/numbers"
+	"github.com/shoenig/go-conceal"
+)
+
+// Bind uses the struct tags of the struct pointed to by target to parse the
+// given url.Values into the fields of target.
+//
+// Fields are described with a form tag naming the form field, and optionally
+// a default tag providing a value to use when the field is missing.
+//
+//	type Signup struct {
+//	  Email    string        `form:"email,required"`
+//	  Password *conceal.Text `form:"password,required"`
+//	  Plan     string        `form:"plan" default:"free"`
+//	  Tags     []string      `form:"tag"`
+//	  Address  struct {
+//	    City string `form:"city"` // parsed from address.city or address[city]
+//	  } `form:"address"`
+//	}
+//
+// The form tag may include the option required, in which case an error is
+// returned if the field is missing. Fields that are not required and have no
+// default are left unchanged when missing.
+//
+// Supported field types are string, bool, *conceal.Text, time.Duration,
+// time.Time, and each of the integer and floating point types. The layout of
+// a time.Time field may be set with a layout tag, otherwise time.RFC3339 is
+// used. Slices of string, int, float64, and bool are parsed from every value
+// of a repeated field; the default of a slice is a comma separated list.
+//
+// A bool field is parsed as with Checkbox: it is true if the value is one of
+// CheckboxValues, and false if the field is missing, since browsers omit
+// unchecked checkboxes. A required bool field must be checked.
+//
+// Nested struct fields are bound recursively; if a nested struct field has a
+// form tag, its name is used as a prefix for the names of the fields in the
+// nested struct, in either dotted (address.city) or bracketed (address[city])
+// notation. Fields without a form tag, or with the tag form:"-", are ignored.
+//
+// Any Errors are keyed by the field name as submitted, e.g. address[city], or
+// by the dotted name for a missing field, e.g. address.city.
+func Bind(values url.Values, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a non-nil pointer to a struct, got %T", target)
+	}
+
+	schema := make(Schema)
+	if err := bindStruct(schema, "", rv.Elem()); err != nil {
+		return err
+	}
+
+	normalized, submitted := normalize(values)
+	if errs, ok := Parse(normalized, schema).(Errors); ok {
+		return errs.rename(submitted)
+	}
+	return nil
+}
+
+// normalize rewrites bracketed field names into dotted field names, such that
+// address[city] becomes address.city, and tags[] becomes tags. The returned
+// map holds the submitted name of each rewritten name, where the first name
+// in sorted order is used if several names are rewritten to the same name.
+func normalize(values url.Values) (url.Values, map[string]string) {
+	result := make(url.Values, len(values))
+	submitted := make(map[string]string)
+	for _, original := range slices.Sorted(maps.Keys(values)) {
+		name := original
+		if strings.Contains(name, "[") {
+			name = strings.ReplaceAll(name, "[]", "")
+			name = strings.ReplaceAll(name, "][", ".")
+			name = strings.ReplaceAll(name, "[", ".")
+			name = strings.ReplaceAll(name, "]", "")
+			if _, exists := submitted[name]; !exists {
+				submitted[name] = original
+			}
+		}
+		result[name] = append(result[name], values[original]...)
+	}
+	return result, submitted
+}
+
+// rename returns the errors of e keyed by the names in names, for the fields
+// that have one.
+func (e Errors) rename(names map[string]string) Errors {
+	result := make(Errors, len(e))
+	for field, errs := range e {
+		name, exists := names[field]
+		if !exists {
+			result[field] = errs
+			continue
+		}
+		for _, err := range errs {
+			if fe, ok := err.(*FieldError); ok {
+				fe.Field = name
+			}
+			result[name] = append(result[name], err)
+		}
+	}
+	return result
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func bindStruct(schema Schema, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("form")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			nested := prefix
+			if name != "" {
+				nested = prefix + name + "."
+			}
+			if err := bindStruct(schema, nested, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !tagged {
+			continue
+		}
+
+		if name == "" {
+			return fmt.Errorf("field %s: form tag is missing a field name", field.Name)
+		}
+
+		required := false
+		for _, option := range strings.Split(options, ",") {
+			switch option {
+			case "":
+			case "required":
+				required = true
+			default:
+				return fmt.Errorf("field %s: unknown form tag option %q", field.Name, option)
+			}
+		}
+
+		parser, err := bindParser(fv, field.Tag.Get("layout"), required)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if value, exists := field.Tag.Lookup("default"); exists {
+			values := []string{value}
+			if field.Type.Kind() == reflect.Slice {
+				values = strings.Split(value, ",")
+			}
+			parser = &defaultParser{values: values, parser: parser}
+		}
+
+		key := prefix + name
+		if _, exists := schema[key]; exists {
+			return fmt.Errorf("field %s: form field %q is declared more than once", field.Name, key)
+		}
+		schema[key] = parser
+	}
+	return nil
+}
+
+func bindParser(v reflect.Value, layout string, required bool) (Parser, error) {
+	switch destination := v.Addr().Interface().(type) {
+	case *string:
+		return &stringParser{required: required, destination: destination}, nil
+	case **conceal.Text:
+		return &secretParser{required: required, destination: destination}, nil
+	case *bool:
+		return &checkboxParser{required: required, vocabulary: Vocabulary{True: CheckboxValues}, destination: destination}, nil
+	case *time.Duration:
+		return &durationParser{required: required, destination: destination}, nil
+	case *time.Time:
+		return &timeParser{required: required, layout: timeLayout(layout), destination: destination}, nil
+	case *int:
+		return &intParser{required: required, destination: destination}, nil
+	case *int8:
+		return bindInteger(destination, required), nil
+	case *int16:
+		return bindInteger(destination, required), nil
+	case *int32:
+		return bindInteger(destination, required), nil
+	case *int64:
+		return bindInteger(destination, required), nil
+	case *uint:
+		return bindInteger(destination, required), nil
+	case *uint8:
+		return bindInteger(destination, required), nil
+	case *uint16:
+		return bindInteger(destination, required), nil
+	case *uint32:
+		return bindInteger(destination, required), nil
+	case *uint64:
+		return bindInteger(destination, required), nil
+	case *float32:
+		return &numberParser[float32]{required: required, convert: numbers.ParseFloat[float32], destination: destination}, nil
+	case *float64:
+		return &floatParser{required: required, destination: destination}, nil
+	case *[]string:
+		return &sliceParser[string]{required: required, convert: parseString, destination: destination}, nil
+	case *[]int:
+		return &sliceParser[int]{required: required, convert: strconv.Atoi, destination: destination}, nil
+	case *[]float64:
+		return &sliceParser[float64]{required: required, convert: numbers.ParseFloat[float64], destination: destination}, nil
+	case *[]bool:
+		return &sliceParser[bool]{required: required, convert: strconv.ParseBool, destination: destination}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func bindInteger[T numbers.Integer](i *T, required bool) Parser {
+	return &numberParser[T]{
+		required:    required,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+type defaultParser struct {
+	values []string
+	parser Parser
+}
+
+func (p *defaultParser) Parse(values []string) error {
+	if len(values) == 0 {
+		values = p.values
+	}
+	return p.parser.Parse(values)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+type address struct {
+	Street string `form:"street"`
+	City   string `form:"city,required"`
+	Zip    uint32 `form:"zip"`
+}
+
+type signup struct {
+	Email    string        `form:"email,required"`
+	Password *conceal.Text `form:"password,required"`
+	Age      int           `form:"age"`
+	Plan     string        `form:"plan" default:"free"`
+	Agree    bool          `form:"agree"`
+	Budget   float64       `form:"budget"`
+	Trial    time.Duration `form:"trial" default:"72h"`
+	Birthday time.Time     `form:"birthday" layout:"2006-01-02"`
+	Tags     []string      `form:"tag"`
+	Scores   []int         `form:"score" default:"1,2"`
+	Ignored  string        `form:"-"`
+	Untagged string
+	Address  address `form:"address"`
+	Billing  address `form:"billing"`
+}
+
+func Test_Bind(t *testing.T) {
+	values := url.Values{
+		"email":         []string{"bob@example.com"},
+		"password":      []string{"hunter2"},
+		"age":           []string{"45"},
+		"agree":         []string{"on"},
+		"budget":        []string{"9.5"},
+		"birthday":      []string{"1980-02-03"},
+		"tag":           []string{"a", "b"},
+		"Untagged":      []string{"nope"},
+		"address.city":  []string{"Austin"},
+		"address.zip":   []string{"78701"},
+		"billing[city]": []string{"Dallas"},
+		"billing[street]": []string{
+			"1 Main St",
+		},
+	}
+
+	var s signup
+	err := Bind(values, &s)
+	must.NoError(t, err)
+	must.Eq(t, "bob@example.com", s.Email)
+	must.Eq(t, "hunter2", s.Password.Unveil())
+	must.Eq(t, 45, s.Age)
+	must.Eq(t, "free", s.Plan)
+	must.True(t, s.Agree)
+	must.Eq(t, 9.5, s.Budget)
+	must.Eq(t, 72*time.Hour, s.Trial)
+	must.Eq(t, time.Date(1980, 2, 3, 0, 0, 0, 0, time.UTC), s.Birthday)
+	must.Eq(t, []string{"a", "b"}, s.Tags)
+	must.Eq(t, []int{1, 2}, s.Scores)
+	must.Eq(t, "", s.Ignored)
+	must.Eq(t, "", s.Untagged)
+	must.Eq(t, "Austin", s.Address.City)
+	must.Eq(t, 78701, s.Address.Zip)
+	must.Eq(t, "Dallas", s.Billing.City)
+	must.Eq(t, "1 Main St", s.Billing.Street)
+}
+
+func Test_Bind_missing(t *testing.T) {
+	values := url.Values{
+		"email":        []string{"bob@example.com"},
+		"address.city": []string{"Austin"},
+		"billing.city": []string{"Dallas"},
+	}
+
+	var s signup
+	err := Bind(values, &s)
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Bind_malformed(t *testing.T) {
+	values := url.Values{
+		"email":        []string{"bob@example.com"},
+		"password":     []string{"hunter2"},
+		"address.city": []string{"Austin"},
+		"billing.city": []string{"Dallas"},
+		"score":        []string{"1", "x"},
+	}
+
+	var s signup
+	err := Bind(values, &s)
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func Test_Bind_checkbox(t *testing.T) {
+	var target struct {
+		Agree     bool `form:"agree,required"`
+		Subscribe bool `form:"subscribe"`
+		Remember  bool `form:"remember" default:"yes"`
+	}
+	target.Subscribe = true
+
+	err := Bind(url.Values{
+		"agree": []string{"on"},
+	}, &target)
+	must.NoError(t, err)
+	must.True(t, target.Agree)
+	must.False(t, target.Subscribe)
+	must.True(t, target.Remember)
+
+	err = Bind(url.Values{
+		"subscribe": []string{"on"},
+	}, &target)
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = Bind(url.Values{
+		"agree":     []string{"on"},
+		"subscribe": []string{"maybe"},
+	}, &target)
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func Test_Bind_brackets(t *testing.T) {
+	var target struct {
+		Tags  []string `form:"tags"`
+		Inner struct {
+			Deeper struct {
+				Value string `form:"value"`
+			} `form:"deeper"`
+		} `form:"inner"`
+	}
+
+	err := Bind(url.Values{
+		"tags[]":               []string{"a", "b"},
+		"inner[deeper][value]": []string{"x"},
+	}, &target)
+	must.NoError(t, err)
+	must.Eq(t, []string{"a", "b"}, target.Tags)
+	must.Eq(t, "x", target.Inner.Deeper.Value)
+}
+
+func Test_Bind_brackets_errors(t *testing.T) {
+	var target struct {
+		Address struct {
+			City string `form:"city,required"`
+			Zip  int    `form:"zip"`
+		} `form:"address"`
+	}
+
+	err := Bind(url.Values{
+		"address[zip]": []string{"abc"},
+	}, &target)
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.Eq(t, []string{"address.city", "address[zip]"}, errs.Fields())
+	must.ErrorIs(t, errs.Get("address[zip]"), strconv.ErrSyntax)
+	must.StrContains(t, errs.Get("address[zip]").Error(), `"address[zip]"`)
+	must.ErrorIs(t, errs.Get("address.city"), ErrNoValue)
+}
+
+func Test_Bind_invalid(t *testing.T) {
+	t.Run("not a pointer", func(t *testing.T) {
+		err := Bind(nil, signup{})
+		must.ErrorContains(t, err, "must be a non-nil pointer to a struct")
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		var target struct {
+			C complex128 `form:"c"`
+		}
+		err := Bind(nil, &target)
+		must.EqError(t, err, "field C: unsupported type complex128")
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		var target struct {
+			S string `form:"s,optional"`
+		}
+		err := Bind(nil, &target)
+		must.EqError(t, err, `field S: unknown form tag option "optional"`)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		var target struct {
+			A string `form:"x"`
+			B string `form:"x"`
+		}
+		err := Bind(nil, &target)
+		must.EqError(t, err, `field B: form field "x" is declared more than once`)
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"github.com/shoenig/extractors/internal/boolean"
+)
+
+// A Vocabulary is the set of values recognized as true and as false by the
+// BoolWith and BoolWithOr parsers. Values are matched case-insensitively.
+type Vocabulary = boolean.Vocabulary
+
+// CheckboxValues are the values recognized as a checked checkbox by Checkbox,
+// unless other values are given. Browsers send "on" for a checked checkbox
+// without a value attribute.
+var CheckboxValues = []string{"on", "yes", "1", "true"}
+
+type checkboxParser struct {
+	required    bool
+	vocabulary  Vocabulary
+	destination *bool
+}
+
+// Checkbox is used to extract the state of an html checkbox into a Go bool.
+// Browsers omit unchecked checkboxes from the form entirely, so a missing
+// value is false. A value in values is true; if no values are given, then
+// CheckboxValues are used instead. Any other value is an error.
+//
+//	<input type="checkbox" name="subscribe">
+func Checkbox(b *bool, values ...string) Parser {
+	if len(values) == 0 {
+		values = CheckboxValues
+	}
+	return &checkboxParser{
+		vocabulary:  Vocabulary{True: values},
+		destination: b,
+	}
+}
+
+func (p *checkboxParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		*p.destination = false
+		return nil
+	}
+
+	b, err := p.vocabulary.Parse(values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = b
+	return nil
+}
+
+type vocabularyParser struct {
+	required    bool
+	vocabulary  Vocabulary
+	destination *bool
+}
+
+// BoolWith is used to extract a form data value into a Go bool, recognizing
+// only the values of vocabulary. If the value is not in the vocabulary or is
+// missing then an error is returned during parsing.
+func BoolWith(b *bool, vocabulary Vocabulary) Parser {
+	return &vocabularyParser{
+		required:    true,
+		vocabulary:  vocabulary,
+		destination: b,
+	}
+}
+
+// BoolWithOr is used to extract a form data value into a Go bool, recognizing
+// only the values of vocabulary. If the value is missing, then the alt value
+// is used instead.
+func BoolWithOr(b *bool, vocabulary Vocabulary, alt bool) Parser {
+	*b = alt
+	return &vocabularyParser{
+		required:    false,
+		vocabulary:  vocabulary,
+		destination: b,
+	}
+}
+
+func (p *vocabularyParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	b, err := p.vocabulary.Parse(values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = b
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Checkbox(t *testing.T) {
+	data := url.Values{
+		"on":     []string{"on"},
+		"yes":    []string{"Yes"},
+		"one":    []string{"1"},
+		"true":   []string{"true"},
+		"custom": []string{"agree"},
+	}
+
+	var on, yes, one, truth, custom, missing bool
+	missing = true
+
+	err := Parse(data, Schema{
+		"on":      Checkbox(&on),
+		"yes":     Checkbox(&yes),
+		"one":     Checkbox(&one),
+		"true":    Checkbox(&truth),
+		"custom":  Checkbox(&custom, "agree"),
+		"missing": Checkbox(&missing),
+	})
+	must.NoError(t, err)
+	must.True(t, on)
+	must.True(t, yes)
+	must.True(t, one)
+	must.True(t, truth)
+	must.True(t, custom)
+	must.False(t, missing)
+}
+
+func Test_Parse_Checkbox_fail(t *testing.T) {
+	var b bool
+
+	err := Parse(url.Values{"x": []string{"off"}}, Schema{
+		"x": Checkbox(&b),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = Parse(url.Values{"x": []string{"on"}}, Schema{
+		"x": Checkbox(&b, "agree"),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = Parse(url.Values{"x": []string{"on", "on"}}, Schema{
+		"x": Checkbox(&b),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+}
+
+func Test_Parse_BoolWith(t *testing.T) {
+	vocabulary := Vocabulary{
+		True:  []string{"y"},
+		False: []string{"n"},
+	}
+
+	data := url.Values{
+		"a": []string{"y"},
+		"b": []string{"N"},
+		"c": []string{"true"},
+	}
+
+	var a, b, c, d bool
+	err := Parse(data, Schema{
+		"a": BoolWith(&a, vocabulary),
+		"b": BoolWithOr(&b, vocabulary, true),
+		"d": BoolWithOr(&d, vocabulary, true),
+	})
+	must.NoError(t, err)
+	must.True(t, a)
+	must.False(t, b)
+	must.True(t, d)
+
+	err = Parse(data, Schema{
+		"c": BoolWith(&c, vocabulary),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.Eq(t, `expected one of "y", "n": invalid syntax`, err.(Errors).Message("c"))
+
+	err = Parse(data, Schema{
+		"missing": BoolWith(&c, vocabulary),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -7,10 +7,11 @@ package formdata
 
 import (
 	"errors"
-	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/shoenig/go-conceal"
 )
@@ -22,12 +23,28 @@ var (
 	ErrParseFailure    = errors.New("could not parse value")
 )
 
+// Parse uses the given schema to extract values from data. Every field in the
+// schema is parsed, and if any fail the returned error is of type Errors,
+// containing the errors for each field that failed to parse.
 func Parse(data url.Values, schema Schema) error {
+	return parse(data, nil, schema)
+}
+
+func parse(data url.Values, files map[string][]*multipart.FileHeader, schema Schema) error {
+	errs := make(Errors)
 	for name, parser := range schema {
-		values := data[name]
-		if err := parser.Parse(values); err != nil {
-			return fmt.Errorf("%s: %w", ErrParseFailure.Error(), err)
+		var err error
+		if fp, ok := parser.(FileParser); ok && files != nil {
+			err = fp.ParseFiles(files[name])
+		} else {
+			err = parser.Parse(data[name])
 		}
+		if err != nil {
+			errs.add(name, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
@@ -45,9 +62,6 @@ func ParseForm(r *http.Request, schema Schema) error {
 // http.Handler responding to an inbound request.
 type Schema map[string]Parser
 
-// do we care about multi-value? we could provide parsers into slices
-// automatically, for example
-
 // A Parser implementation is capable of extracting a value from the value of
 // an url.Values, which is a slice of string.
 type Parser interface {
@@ -249,3 +263,104 @@ func (p *boolParser) Parse(values []string) error {
 	*p.destination = b
 	return nil
 }
+
+type durationParser struct {
+	required    bool
+	destination *time.Duration
+}
+
+// Duration is used to extract a form data value into a Go time.Duration, using
+// the format accepted by time.ParseDuration. If the value is not a duration or
+// is missing then an error is returned during parsing.
+func Duration(d *time.Duration) Parser {
+	return &durationParser{
+		required:    true,
+		destination: d,
+	}
+}
+
+// DurationOr is used to extract a form data value into a Go time.Duration. If
+// the value is missing, then the alt value is used instead.
+func DurationOr(d *time.Duration, alt time.Duration) Parser {
+	*d = alt
+	return &durationParser{
+		required:    false,
+		destination: d,
+	}
+}
+
+func (p *durationParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	d, err := time.ParseDuration(values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = d
+	return nil
+}
+
+type timeParser struct {
+	required    bool
+	layout      string
+	destination *time.Time
+}
+
+func timeLayout(layout string) string {
+	if layout == "" {
+		return time.RFC3339
+	}
+	return layout
+}
+
+// Time is used to extract a form data value into a Go time.Time, using the
+// given layout as accepted by time.Parse. If layout is empty, then
+// time.RFC3339 is used. If the value is not a time in the layout or is missing
+// then an error is returned during parsing.
+func Time(t *time.Time, layout string) Parser {
+	return &timeParser{
+		required:    true,
+		layout:      timeLayout(layout),
+		destination: t,
+	}
+}
+
+// TimeOr is used to extract a form data value into a Go time.Time, using the
+// given layout as accepted by time.Parse. If layout is empty, then
+// time.RFC3339 is used. If the value is missing, then the alt value is used
+// instead.
+func TimeOr(t *time.Time, layout string, alt time.Time) Parser {
+	*t = alt
+	return &timeParser{
+		required:    false,
+		layout:      timeLayout(layout),
+		destination: t,
+	}
+}
+
+func (p *timeParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	t, err := time.Parse(p.layout, values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = t
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/shoenig/go-conceal"
 	"github.com/shoenig/test/must"
@@ -248,3 +249,60 @@ func Test_Parse_bool_malformed(t *testing.T) {
 	})
 	must.Error(t, err)
 }
+
+func Test_Parse_time(t *testing.T) {
+	data := url.Values{
+		"timeout": []string{"1m30s"},
+		"start":   []string{"2024-05-06T07:08:09Z"},
+		"date":    []string{"2024-05-06"},
+	}
+
+	var (
+		timeout  time.Duration
+		ttl      time.Duration
+		start    time.Time
+		date     time.Time
+		deadline time.Time
+	)
+
+	fallback := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := Parse(data, Schema{
+		"timeout":  Duration(&timeout),
+		"ttl":      DurationOr(&ttl, 5*time.Second),
+		"start":    Time(&start, ""),
+		"date":     Time(&date, time.DateOnly),
+		"deadline": TimeOr(&deadline, "", fallback),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 90*time.Second, timeout)
+	must.Eq(t, 5*time.Second, ttl)
+	must.Eq(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), start)
+	must.Eq(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), date)
+	must.Eq(t, fallback, deadline)
+}
+
+func Test_Parse_time_malformed(t *testing.T) {
+	data := url.Values{
+		"timeout": []string{"90"},
+		"start":   []string{"yesterday"},
+	}
+
+	var timeout time.Duration
+	err := Parse(data, Schema{
+		"timeout": Duration(&timeout),
+	})
+	must.Error(t, err)
+
+	var start time.Time
+	err = Parse(data, Schema{
+		"start": Time(&start, ""),
+	})
+	must.Error(t, err)
+
+	var missing time.Time
+	err = Parse(data, Schema{
+		"missing": Time(&missing, ""),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// A FieldError describes the failure to parse a single form field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %q: %v", ErrParseFailure.Error(), e.Field, e.Err)
+}
+
+// Unwrap returns ErrParseFailure and the underlying cause of the failure.
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrParseFailure, e.Err}
+}
+
+// Errors is the collection of every FieldError encountered while parsing a
+// Schema, keyed by the name of the form field as submitted, including the
+// bracketed names such as address[city] accepted by Bind. The built-in parsers
+// report at most one error per field, but a custom Parser may report several
+// by returning an error with an Unwrap() []error method, such as one created
+// with errors.Join or with fmt.Errorf and several %w verbs, in which case each
+// of the wrapped errors is recorded separately. A *FieldError or Errors
+// returned by a custom Parser is recorded as is.
+//
+// The helper methods are safe to use on a nil Errors, which makes it
+// convenient to pass to an html/template when re-rendering a form.
+//
+//	{{ with .Errors.Message "email" }}<span class="error">{{ . }}</span>{{ end }}
+type Errors map[string][]error
+
+func (e Errors) add(field string, err error) {
+	switch multi := err.(type) {
+	case *FieldError, Errors:
+	case interface{ Unwrap() []error }:
+		for _, cause := range multi.Unwrap() {
+			e[field] = append(e[field], &FieldError{Field: field, Err: cause})
+		}
+		return
+	}
+	e[field] = append(e[field], &FieldError{Field: field, Err: err})
+}
+
+// Fields returns the names of the fields that failed to parse, in sorted
+// order.
+func (e Errors) Fields() []string {
+	return slices.Sorted(maps.Keys(e))
+}
+
+// Has returns whether field failed to parse.
+func (e Errors) Has(field string) bool {
+	return len(e[field]) > 0
+}
+
+// Get returns the first error for field, or nil if field parsed successfully.
+func (e Errors) Get(field string) error {
+	if !e.Has(field) {
+		return nil
+	}
+	return e[field][0]
+}
+
+// Message returns the message of the underlying cause of each error for
+// field, joined by "; ", or the empty string if field parsed successfully.
+// Unlike Get, the message does not include the name of the field, making it
+// suitable for displaying next to the input in a re-rendered form.
+func (e Errors) Message(field string) string {
+	msgs := make([]string, 0, len(e[field]))
+	for _, err := range e[field] {
+		if fe, ok := err.(*FieldError); ok {
+			msgs = append(msgs, fe.Err.Error())
+		} else {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Error() string {
+	var msgs []string
+	for _, field := range e.Fields() {
+		for _, err := range e[field] {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns each FieldError sorted by field name, enabling the use of
+// errors.Is and errors.As on the underlying causes.
+func (e Errors) Unwrap() []error {
+	var errs []error
+	for _, field := range e.Fields() {
+		errs = append(errs, e[field]...)
+	}
+	return errs
+}
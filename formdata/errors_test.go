@@ -0,0 +1,108 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Errors(t *testing.T) {
+	data := url.Values{
+		"name":  []string{"bob"},
+		"age":   []string{"abc"},
+		"email": []string{"a@example.com", "b@example.com"},
+	}
+
+	var (
+		name  string
+		age   int
+		email string
+		zip   string
+	)
+
+	err := Parse(data, Schema{
+		"name":  String(&name),
+		"age":   Int(&age),
+		"email": String(&email),
+		"zip":   String(&zip),
+	})
+	must.Error(t, err)
+	must.ErrorIs(t, err, ErrParseFailure)
+	must.ErrorIs(t, err, ErrNoValue)
+	must.ErrorIs(t, err, ErrMulitpleValues)
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.EqError(t, err, `could not parse value: "age": strconv.Atoi: parsing "abc": invalid syntax; `+
+		`could not parse value: "email": expected only one value to exist; `+
+		`could not parse value: "zip": expected value to exist`)
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.Eq(t, []string{"age", "email", "zip"}, errs.Fields())
+
+	must.False(t, errs.Has("name"))
+	must.NoError(t, errs.Get("name"))
+	must.Eq(t, "", errs.Message("name"))
+
+	must.True(t, errs.Has("zip"))
+	must.ErrorIs(t, errs.Get("zip"), ErrNoValue)
+	must.ErrorIs(t, errs.Get("zip"), ErrParseFailure)
+	must.Eq(t, "expected value to exist", errs.Message("zip"))
+
+	var fe *FieldError
+	must.True(t, errors.As(errs.Get("age"), &fe))
+	must.Eq(t, "age", fe.Field)
+	must.ErrorIs(t, fe.Err, strconv.ErrSyntax)
+}
+
+func Test_Parse_Errors_none(t *testing.T) {
+	var name string
+	err := Parse(url.Values{"name": []string{"bob"}}, Schema{
+		"name": String(&name),
+	})
+	must.Nil(t, err)
+}
+
+func Test_Errors_joined(t *testing.T) {
+	errs := make(Errors)
+	errs.add("upload", errors.Join(ErrFileTooLarge, ErrFileType))
+	must.Len(t, 2, errs["upload"])
+	must.ErrorIs(t, errs["upload"][0], ErrFileTooLarge)
+	must.ErrorIs(t, errs["upload"][1], ErrFileType)
+	must.Eq(t, ErrFileTooLarge.Error()+"; "+ErrFileType.Error(), errs.Message("upload"))
+}
+
+func Test_Errors_wrapped(t *testing.T) {
+	errs := make(Errors)
+	errs.add("upload", fmt.Errorf("%w and %w", ErrFileTooLarge, ErrFileType))
+	must.Len(t, 2, errs["upload"])
+	must.ErrorIs(t, errs["upload"][0], ErrFileTooLarge)
+	must.ErrorIs(t, errs["upload"][1], ErrFileType)
+}
+
+func Test_Errors_nested(t *testing.T) {
+	nested := make(Errors)
+	nested.add("city", ErrNoValue)
+
+	errs := make(Errors)
+	errs.add("address", nested)
+	errs.add("upload", &FieldError{Field: "file", Err: ErrFileType})
+	must.Len(t, 1, errs["address"])
+	must.ErrorIs(t, errs["address"][0], ErrNoValue)
+	must.Len(t, 1, errs["upload"])
+	must.ErrorIs(t, errs["upload"][0], ErrFileType)
+}
+
+func Test_Errors_nil(t *testing.T) {
+	var errs Errors
+	must.False(t, errs.Has("x"))
+	must.NoError(t, errs.Get("x"))
+	must.Eq(t, "", errs.Message("x"))
+	must.SliceEmpty(t, errs.Fields())
+}
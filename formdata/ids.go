@@ -0,0 +1,103 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"github.com/shoenig/extractors/internal/ids"
+)
+
+type idParser[T any] struct {
+	required    bool
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (p *idParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	id, err := p.convert(values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = id
+	return nil
+}
+
+// UUID is used to extract a form data value in the canonical RFC 9562 form
+// into u, which may be any type of [16]byte such as uuid.UUID. If versions are
+// given, then the UUID must be one of those versions. If the value is not a
+// UUID or is missing then an error is returned during parsing.
+func UUID[T ~[16]byte](u *T, versions ...int) Parser {
+	return &idParser[T]{
+		required:    true,
+		convert:     ids.UUIDFunc[T](versions...),
+		destination: u,
+	}
+}
+
+// UUIDOr is used to extract a form data value in the canonical RFC 9562 form
+// into u, which may be any type of [16]byte such as uuid.UUID. If the value is
+// missing, then the alt value is used instead.
+func UUIDOr[T ~[16]byte](u *T, alt T, versions ...int) Parser {
+	*u = alt
+	return &idParser[T]{
+		required:    false,
+		convert:     ids.UUIDFunc[T](versions...),
+		destination: u,
+	}
+}
+
+// ULID is used to extract a form data value into u, which may be any type of
+// [16]byte such as ulid.ULID. If the value is not a ULID or is missing then an
+// error is returned during parsing.
+func ULID[T ~[16]byte](u *T) Parser {
+	return &idParser[T]{
+		required:    true,
+		convert:     ids.ParseULID[T],
+		destination: u,
+	}
+}
+
+// ULIDOr is used to extract a form data value into u, which may be any type
+// of [16]byte such as ulid.ULID. If the value is missing, then the alt value
+// is used instead.
+func ULIDOr[T ~[16]byte](u *T, alt T) Parser {
+	*u = alt
+	return &idParser[T]{
+		required:    false,
+		convert:     ids.ParseULID[T],
+		destination: u,
+	}
+}
+
+// KSUID is used to extract a form data value into k, which may be any type of
+// [20]byte such as ksuid.KSUID. If the value is not a KSUID or is missing then
+// an error is returned during parsing.
+func KSUID[T ~[20]byte](k *T) Parser {
+	return &idParser[T]{
+		required:    true,
+		convert:     ids.ParseKSUID[T],
+		destination: k,
+	}
+}
+
+// KSUIDOr is used to extract a form data value into k, which may be any type
+// of [20]byte such as ksuid.KSUID. If the value is missing, then the alt value
+// is used instead.
+func KSUIDOr[T ~[20]byte](k *T, alt T) Parser {
+	*k = alt
+	return &idParser[T]{
+		required:    false,
+		convert:     ids.ParseKSUID[T],
+		destination: k,
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type userID [16]byte
+
+func Test_Parse_ids(t *testing.T) {
+	data := url.Values{
+		"user":  []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+		"order": []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		"event": []string{"0ujtsYcgvSTl8PAuAdqWYSMnLOv"},
+	}
+
+	var (
+		user     userID
+		order    [16]byte
+		event    [20]byte
+		fallback = userID{1}
+		other    userID
+		batch    [16]byte
+		stream   [20]byte
+	)
+
+	err := Parse(data, Schema{
+		"user":   UUID(&user),
+		"order":  ULID(&order),
+		"event":  KSUID(&event),
+		"other":  UUIDOr(&other, fallback, 4),
+		"batch":  ULIDOr(&batch, [16]byte{2}),
+		"stream": KSUIDOr(&stream, [20]byte{3}),
+	})
+	must.NoError(t, err)
+	must.EqOp(t, 0xf4, user[0])
+	must.EqOp(t, 0x01, order[0])
+	must.EqOp(t, 0x06, event[0])
+	must.Eq(t, fallback, other)
+	must.Eq(t, [16]byte{2}, batch)
+	must.Eq(t, [20]byte{3}, stream)
+}
+
+func Test_Parse_ids_fail(t *testing.T) {
+	var user userID
+
+	err := Parse(url.Values{"user": []string{"nope"}}, Schema{
+		"user": UUID(&user),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = Parse(url.Values{}, Schema{
+		"user": UUID(&user),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	var order [16]byte
+	err = Parse(url.Values{"order": []string{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ"}}, Schema{
+		"order": ULID(&order),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrFileTooLarge = errors.New("file is too large")
+	ErrTooManyFiles = errors.New("too many files")
+	ErrTooFewFiles  = errors.New("too few files")
+	ErrFileType     = errors.New("file type is not allowed")
+)
+
+// ParseMultipart parses the multipart/form-data body of r, storing up to
+// maxMemory bytes of uploaded files in memory and the remainder on disk (see
+// http.Request.ParseMultipartForm), and then parses the form values and files
+// given the field names and parsers defined in schema.
+//
+// Uploaded files are extracted using the File and Files parsers.
+func ParseMultipart(r *http.Request, maxMemory int64, schema Schema) error {
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return err
+	}
+
+	return parse(r.Form, r.MultipartForm.File, schema)
+}
+
+// ParseMultipartForm parses the values and files of an already parsed
+// multipart form given the field names and parsers defined in schema.
+//
+// Uploaded files are extracted using the File and Files parsers.
+func ParseMultipartForm(form *multipart.Form, schema Schema) error {
+	return parse(form.Value, form.File, schema)
+}
+
+// A FileParser is a Parser that is also capable of extracting uploaded files
+// from a multipart form. When parsing a multipart form, ParseFiles is used
+// instead of Parse for any Parser that implements FileParser.
+type FileParser interface {
+	Parser
+	ParseFiles([]*multipart.FileHeader) error
+}
+
+// A FileOption configures the constraints on files extracted by the File and
+// Files parsers.
+type FileOption func(*fileOptions)
+
+type fileOptions struct {
+	maxSize      int64
+	minCount     int
+	maxCount     int
+	contentTypes []string
+	extensions   []string
+}
+
+// MaxFileSize causes an error wrapping ErrFileTooLarge if any file is larger
+// than n bytes.
+func MaxFileSize(n int64) FileOption {
+	return func(o *fileOptions) {
+		o.maxSize = n
+	}
+}
+
+// MinFiles causes an error wrapping ErrTooFewFiles if fewer than n files are
+// uploaded. The default is 1, and so MinFiles(0) makes files optional.
+func MinFiles(n int) FileOption {
+	return func(o *fileOptions) {
+		o.minCount = n
+	}
+}
+
+// MaxFiles causes an error wrapping ErrTooManyFiles if more than n files are
+// uploaded. Only applicable to Files.
+func MaxFiles(n int) FileOption {
+	return func(o *fileOptions) {
+		o.maxCount = n
+	}
+}
+
+// ContentTypes causes an error wrapping ErrFileType if the content type of any
+// file is not one of types. The content type is detected from the content of
+// the file using http.DetectContentType, rather than trusting the client. A
+// type may be a wildcard such as "image/*".
+func ContentTypes(types ...string) FileOption {
+	return func(o *fileOptions) {
+		o.contentTypes = types
+	}
+}
+
+// Extensions causes an error wrapping ErrFileType if the filename extension of
+// any file is not one of extensions, e.g. ".png". Extensions are compared
+// without regard to case. Combine with ContentTypes to also verify the content
+// of the file.
+func Extensions(extensions ...string) FileOption {
+	return func(o *fileOptions) {
+		for _, extension := range extensions {
+			extension = strings.ToLower(extension)
+			if !strings.HasPrefix(extension, ".") {
+				extension = "." + extension
+			}
+			o.extensions = append(o.extensions, extension)
+		}
+	}
+}
+
+// File is used to extract a single uploaded file from a multipart form. If the
+// file is missing, more than one file is uploaded, or the file does not meet
+// the constraints of opts, then an error is returned during parsing.
+func File(fh **multipart.FileHeader, opts ...FileOption) Parser {
+	options := fileOptions{minCount: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &fileParser{
+		single:  true,
+		options: options,
+		assign: func(files []*multipart.FileHeader) {
+			if len(files) > 0 {
+				*fh = files[0]
+			}
+		},
+	}
+}
+
+// Files is used to extract every file uploaded for a field of a multipart
+// form. If no files are uploaded, or any file does not meet the constraints
+// of opts, then an error is returned during parsing.
+func Files(fhs *[]*multipart.FileHeader, opts ...FileOption) Parser {
+	options := fileOptions{minCount: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &fileParser{
+		options: options,
+		assign: func(files []*multipart.FileHeader) {
+			if len(files) > 0 {
+				*fhs = slices.Clone(files)
+			}
+		},
+	}
+}
+
+type fileParser struct {
+	single  bool
+	options fileOptions
+	assign  func([]*multipart.FileHeader)
+}
+
+// Parse handles a file field of a form that is not multipart, which can never
+// contain any files.
+func (p *fileParser) Parse([]string) error {
+	return p.ParseFiles(nil)
+}
+
+func (p *fileParser) ParseFiles(files []*multipart.FileHeader) error {
+	switch {
+	case len(files) == 0 && p.options.minCount > 0:
+		return ErrNoValue
+	case len(files) > 1 && p.single:
+		return ErrMulitpleValues
+	case len(files) < p.options.minCount:
+		return fmt.Errorf("%w: expected at least %d, got %d", ErrTooFewFiles, p.options.minCount, len(files))
+	case p.options.maxCount > 0 && len(files) > p.options.maxCount:
+		return fmt.Errorf("%w: expected at most %d, got %d", ErrTooManyFiles, p.options.maxCount, len(files))
+	}
+
+	for _, file := range files {
+		if err := p.check(file); err != nil {
+			return err
+		}
+	}
+
+	p.assign(files)
+	return nil
+}
+
+func (p *fileParser) check(file *multipart.FileHeader) error {
+	if p.options.maxSize > 0 && file.Size > p.options.maxSize {
+		return fmt.Errorf("%w: %q is %d bytes, limit is %d", ErrFileTooLarge, file.Filename, file.Size, p.options.maxSize)
+	}
+
+	if len(p.options.extensions) > 0 {
+		extension := strings.ToLower(filepath.Ext(file.Filename))
+		if !slices.Contains(p.options.extensions, extension) {
+			return fmt.Errorf("%w: %q does not have an allowed extension", ErrFileType, file.Filename)
+		}
+	}
+
+	if len(p.options.contentTypes) > 0 {
+		contentType, err := detect(file)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(p.options.contentTypes, func(allowed string) bool {
+			return matchContentType(allowed, contentType)
+		}) {
+			return fmt.Errorf("%w: %q has content type %q", ErrFileType, file.Filename, contentType)
+		}
+	}
+
+	return nil
+}
+
+// detect returns the media type of the content of file.
+func detect(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	if err != nil {
+		return "", err
+	}
+	return mediaType, nil
+}
+
+func matchContentType(allowed, contentType string) bool {
+	if prefix, found := strings.CutSuffix(allowed, "/*"); found {
+		return strings.HasPrefix(contentType, prefix+"/")
+	}
+	return strings.EqualFold(allowed, contentType)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+var pngContent = "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 32)
+
+type upload struct {
+	field    string
+	filename string
+	content  string
+}
+
+func newMultipartRequest(t *testing.T, values map[string]string, uploads ...upload) *http.Request {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for name, value := range values {
+		must.NoError(t, w.WriteField(name, value))
+	}
+	for _, u := range uploads {
+		part, err := w.CreateFormFile(u.field, u.filename)
+		must.NoError(t, err)
+		_, err = part.Write([]byte(u.content))
+		must.NoError(t, err)
+	}
+	must.NoError(t, w.Close())
+
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/upload", body)
+	must.NoError(t, err)
+	request.Header.Set("Content-Type", w.FormDataContentType())
+	return request
+}
+
+func Test_ParseMultipart(t *testing.T) {
+	request := newMultipartRequest(t,
+		map[string]string{"title": "holiday", "count": "2"},
+		upload{field: "avatar", filename: "me.PNG", content: pngContent},
+		upload{field: "docs", filename: "a.txt", content: "hello"},
+		upload{field: "docs", filename: "b.txt", content: "world"},
+	)
+
+	var (
+		title    string
+		count    int
+		avatar   *multipart.FileHeader
+		docs     []*multipart.FileHeader
+		optional *multipart.FileHeader
+	)
+
+	err := ParseMultipart(request, 1<<20, Schema{
+		"title":    String(&title),
+		"count":    Int(&count),
+		"avatar":   File(&avatar, MaxFileSize(1024), ContentTypes("image/*"), Extensions("png", ".jpg")),
+		"docs":     Files(&docs, MaxFiles(3), ContentTypes("text/plain")),
+		"optional": File(&optional, MinFiles(0)),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "holiday", title)
+	must.Eq(t, 2, count)
+	must.Eq(t, "me.PNG", avatar.Filename)
+	must.Len(t, 2, docs)
+	must.Eq(t, "a.txt", docs[0].Filename)
+	must.Eq(t, "b.txt", docs[1].Filename)
+	must.Nil(t, optional)
+}
+
+func Test_ParseMultipartForm(t *testing.T) {
+	request := newMultipartRequest(t,
+		map[string]string{"title": "holiday"},
+		upload{field: "doc", filename: "a.txt", content: "hello"},
+	)
+	must.NoError(t, request.ParseMultipartForm(1<<20))
+
+	var (
+		title string
+		doc   *multipart.FileHeader
+	)
+
+	err := ParseMultipartForm(request.MultipartForm, Schema{
+		"title": String(&title),
+		"doc":   File(&doc),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "holiday", title)
+	must.Eq(t, "a.txt", doc.Filename)
+}
+
+func Test_ParseMultipart_fail(t *testing.T) {
+	cases := []struct {
+		name    string
+		uploads []upload
+		parser  func(*[]*multipart.FileHeader) Parser
+		is      error
+	}{
+		{
+			name:   "missing",
+			parser: func(fhs *[]*multipart.FileHeader) Parser { return Files(fhs) },
+			is:     ErrNoValue,
+		},
+		{
+			name: "too large",
+			uploads: []upload{
+				{field: "f", filename: "a.txt", content: "hello world"},
+			},
+			parser: func(fhs *[]*multipart.FileHeader) Parser { return Files(fhs, MaxFileSize(5)) },
+			is:     ErrFileTooLarge,
+		},
+		{
+			name: "too many",
+			uploads: []upload{
+				{field: "f", filename: "a.txt", content: "a"},
+				{field: "f", filename: "b.txt", content: "b"},
+			},
+			parser: func(fhs *[]*multipart.FileHeader) Parser { return Files(fhs, MaxFiles(1)) },
+			is:     ErrTooManyFiles,
+		},
+		{
+			name: "too few",
+			uploads: []upload{
+				{field: "f", filename: "a.txt", content: "a"},
+			},
+			parser: func(fhs *[]*multipart.FileHeader) Parser { return Files(fhs, MinFiles(2)) },
+			is:     ErrTooFewFiles,
+		},
+		{
+			name: "extension",
+			uploads: []upload{
+				{field: "f", filename: "a.exe", content: "a"},
+			},
+			parser: func(fhs *[]*multipart.FileHeader) Parser { return Files(fhs, Extensions(".txt")) },
+			is:     ErrFileType,
+		},
+		{
+			name: "content type",
+			uploads: []upload{
+				{field: "f", filename: "fake.png", content: "not really a png"},
+			},
+			parser: func(fhs *[]*multipart.FileHeader) Parser {
+				return Files(fhs, Extensions(".png"), ContentTypes("image/png"))
+			},
+			is: ErrFileType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := newMultipartRequest(t, nil, tc.uploads...)
+
+			var files []*multipart.FileHeader
+			err := ParseMultipart(request, 1<<20, Schema{
+				"f": tc.parser(&files),
+			})
+			must.ErrorIs(t, err, tc.is)
+			must.Nil(t, files)
+		})
+	}
+}
+
+func Test_ParseMultipart_single_file_multiple_uploads(t *testing.T) {
+	request := newMultipartRequest(t, nil,
+		upload{field: "f", filename: "a.txt", content: "a"},
+		upload{field: "f", filename: "b.txt", content: "b"},
+	)
+
+	var file *multipart.FileHeader
+	err := ParseMultipart(request, 1<<20, Schema{
+		"f": File(&file),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+	must.Nil(t, file)
+}
+
+func Test_ParseMultipart_not_multipart(t *testing.T) {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader("a=b"))
+	must.NoError(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var a string
+	err = ParseMultipart(request, 1<<20, Schema{
+		"a": String(&a),
+	})
+	must.ErrorIs(t, err, http.ErrNotMultipart)
+}
+
+func Test_Parse_file_without_multipart(t *testing.T) {
+	var file *multipart.FileHeader
+	err := Parse(url.Values{}, Schema{
+		"f": File(&file),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = Parse(url.Values{}, Schema{
+		"f": File(&file, MinFiles(0)),
+	})
+	must.NoError(t, err)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"github.com/shoenig/extractors/internal/numbers"
+)
+
+type numberParser[T numbers.Number] struct {
+	required    bool
+	bounded     bool
+	lo, hi      T
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (p *numberParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	n, err := p.convert(values[0])
+	if err != nil {
+		return err
+	}
+
+	if p.bounded {
+		if err = numbers.CheckRange(n, p.lo, p.hi); err != nil {
+			return err
+		}
+	}
+
+	*p.destination = n
+	return nil
+}
+
+// Integer is used to extract a form data value into any Go integer type. If
+// the value is not an integer, does not fit in the type, or is missing then an
+// error is returned during parsing.
+func Integer[T numbers.Integer](i *T) Parser {
+	return &numberParser[T]{
+		required:    true,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// IntegerOr is used to extract a form data value into any Go integer type. If
+// the value is missing, then the alt value is used instead.
+func IntegerOr[T numbers.Integer](i *T, alt T) Parser {
+	*i = alt
+	return &numberParser[T]{
+		required:    false,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// IntegerBetween is used to extract a form data value into any Go integer
+// type. If the value is not within the inclusive range of lo to hi, then an
+// error wrapping strconv.ErrRange is returned during parsing.
+func IntegerBetween[T numbers.Integer](i *T, lo, hi T) Parser {
+	return &numberParser[T]{
+		required:    true,
+		bounded:     true,
+		lo:          lo,
+		hi:          hi,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// Floating is used to extract a form data value into any Go floating point
+// type. If the value is not a float, does not fit in the type, or is missing
+// then an error is returned during parsing.
+func Floating[T numbers.Float](f *T) Parser {
+	return &numberParser[T]{
+		required:    true,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
+
+// FloatingOr is used to extract a form data value into any Go floating point
+// type. If the value is missing, then the alt value is used instead.
+func FloatingOr[T numbers.Float](f *T, alt T) Parser {
+	*f = alt
+	return &numberParser[T]{
+		required:    false,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
+
+// FloatingBetween is used to extract a form data value into any Go floating
+// point type. If the value is not within the inclusive range of lo to hi, then
+// an error wrapping strconv.ErrRange is returned during parsing.
+func FloatingBetween[T numbers.Float](f *T, lo, hi T) Parser {
+	return &numberParser[T]{
+		required:    true,
+		bounded:     true,
+		lo:          lo,
+		hi:          hi,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
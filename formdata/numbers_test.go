@@ -0,0 +1,85 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_numbers(t *testing.T) {
+	data := url.Values{
+		"quantity": []string{"12"},
+		"page":     []string{"3"},
+		"weight":   []string{"2.5"},
+		"score":    []string{"0.75"},
+	}
+
+	var (
+		quantity uint8
+		page     int32
+		weight   float32
+		score    float64
+		limit    int16
+		scale    float32
+	)
+
+	err := Parse(data, Schema{
+		"quantity": Integer(&quantity),
+		"page":     IntegerBetween(&page, 1, 100),
+		"weight":   Floating(&weight),
+		"score":    FloatingBetween(&score, 0, 1),
+		"limit":    IntegerOr(&limit, 25),
+		"scale":    FloatingOr(&scale, float32(1.5)),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 12, quantity)
+	must.Eq(t, 3, page)
+	must.Eq(t, 2.5, weight)
+	must.Eq(t, 0.75, score)
+	must.Eq(t, 25, limit)
+	must.Eq(t, 1.5, scale)
+}
+
+func Test_Parse_numbers_fail(t *testing.T) {
+	data := url.Values{
+		"quantity": []string{"256"},
+		"page":     []string{"101"},
+		"weight":   []string{"heavy"},
+	}
+
+	var quantity uint8
+	err := Parse(data, Schema{
+		"quantity": Integer(&quantity),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	var page int
+	err = Parse(data, Schema{
+		"page": IntegerBetween(&page, 1, 100),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+	must.Eq(t, 0, page)
+
+	var score float64
+	err = Parse(url.Values{"score": []string{"nan"}}, Schema{
+		"score": FloatingBetween(&score, 0, 1),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+	must.Eq(t, 0, score)
+
+	var weight float32
+	err = Parse(data, Schema{
+		"weight": Floating(&weight),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = Parse(data, Schema{
+		"missing": Floating(&weight),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/shoenig/extractors/internal/numbers"
+)
+
+var (
+	ErrTooFewValues  = errors.New("too few values")
+	ErrTooManyValues = errors.New("too many values")
+)
+
+// A SliceOption configures the constraints applied by the Strings, Ints,
+// Floats, and Bools parsers to the values of a repeated field, such as a group
+// of checkboxes or a multi-select.
+type SliceOption func(*sliceOptions)
+
+type sliceOptions struct {
+	minCount int
+	maxCount int
+	unique   bool
+}
+
+func newSliceOptions(opts []SliceOption) sliceOptions {
+	var options sliceOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// MinCount causes an error wrapping ErrTooFewValues if there are fewer than n
+// values. When combined with Unique, values are counted after duplicates are
+// removed.
+func MinCount(n int) SliceOption {
+	return func(o *sliceOptions) {
+		o.minCount = n
+	}
+}
+
+// MaxCount causes an error wrapping ErrTooManyValues if there are more than n
+// values. When combined with Unique, values are counted after duplicates are
+// removed.
+func MaxCount(n int) SliceOption {
+	return func(o *sliceOptions) {
+		o.maxCount = n
+	}
+}
+
+// Unique causes duplicate values to be removed, keeping the first occurrence
+// of each value.
+func Unique() SliceOption {
+	return func(o *sliceOptions) {
+		o.unique = true
+	}
+}
+
+// sliceParser extracts every value of a repeated field.
+type sliceParser[T comparable] struct {
+	required    bool
+	options     sliceOptions
+	convert     func(string) (T, error)
+	destination *[]T
+}
+
+func (p *sliceParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	result := make([]T, 0, len(values))
+	seen := make(map[T]bool, len(values))
+	for i, value := range values {
+		v, err := p.convert(value)
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		if p.options.unique {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+		}
+		result = append(result, v)
+	}
+
+	switch {
+	case len(result) < p.options.minCount:
+		return fmt.Errorf("%w: expected at least %d, got %d", ErrTooFewValues, p.options.minCount, len(result))
+	case p.options.maxCount > 0 && len(result) > p.options.maxCount:
+		return fmt.Errorf("%w: expected at most %d, got %d", ErrTooManyValues, p.options.maxCount, len(result))
+	}
+
+	*p.destination = result
+	return nil
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+// Strings is used to extract every value of a repeated form data field into a
+// Go []string, e.g. tag=a&tag=b. If the value is missing then an error is
+// returned during parsing.
+func Strings(s *[]string, opts ...SliceOption) Parser {
+	return &sliceParser[string]{
+		required:    true,
+		options:     newSliceOptions(opts),
+		convert:     parseString,
+		destination: s,
+	}
+}
+
+// StringsOr is used to extract every value of a repeated form data field into
+// a Go []string. If the value is missing, then the alt value is used instead.
+func StringsOr(s *[]string, alt []string, opts ...SliceOption) Parser {
+	*s = alt
+	return &sliceParser[string]{
+		required:    false,
+		options:     newSliceOptions(opts),
+		convert:     parseString,
+		destination: s,
+	}
+}
+
+// Ints is used to extract every value of a repeated form data field into a Go
+// []int. If any value is not an int or the value is missing then an error is
+// returned during parsing.
+func Ints(i *[]int, opts ...SliceOption) Parser {
+	return &sliceParser[int]{
+		required:    true,
+		options:     newSliceOptions(opts),
+		convert:     strconv.Atoi,
+		destination: i,
+	}
+}
+
+// IntsOr is used to extract every value of a repeated form data field into a
+// Go []int. If the value is missing, then the alt value is used instead.
+func IntsOr(i *[]int, alt []int, opts ...SliceOption) Parser {
+	*i = alt
+	return &sliceParser[int]{
+		required:    false,
+		options:     newSliceOptions(opts),
+		convert:     strconv.Atoi,
+		destination: i,
+	}
+}
+
+// Floats is used to extract every value of a repeated form data field into a
+// Go []float64. If any value is not a float or the value is missing then an
+// error is returned during parsing.
+func Floats(f *[]float64, opts ...SliceOption) Parser {
+	return &sliceParser[float64]{
+		required:    true,
+		options:     newSliceOptions(opts),
+		convert:     numbers.ParseFloat[float64],
+		destination: f,
+	}
+}
+
+// FloatsOr is used to extract every value of a repeated form data field into a
+// Go []float64. If the value is missing, then the alt value is used instead.
+func FloatsOr(f *[]float64, alt []float64, opts ...SliceOption) Parser {
+	*f = alt
+	return &sliceParser[float64]{
+		required:    false,
+		options:     newSliceOptions(opts),
+		convert:     numbers.ParseFloat[float64],
+		destination: f,
+	}
+}
+
+// Bools is used to extract every value of a repeated form data field into a Go
+// []bool. If any value is not a bool or the value is missing then an error is
+// returned during parsing.
+func Bools(b *[]bool, opts ...SliceOption) Parser {
+	return &sliceParser[bool]{
+		required:    true,
+		options:     newSliceOptions(opts),
+		convert:     strconv.ParseBool,
+		destination: b,
+	}
+}
+
+// BoolsOr is used to extract every value of a repeated form data field into a
+// Go []bool. If the value is missing, then the alt value is used instead.
+func BoolsOr(b *[]bool, alt []bool, opts ...SliceOption) Parser {
+	*b = alt
+	return &sliceParser[bool]{
+		required:    false,
+		options:     newSliceOptions(opts),
+		convert:     strconv.ParseBool,
+		destination: b,
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package formdata
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_slices(t *testing.T) {
+	data := url.Values{
+		"tag":   []string{"a", "b", "a"},
+		"id":    []string{"1", "2", "3"},
+		"score": []string{"1.5", "2.5"},
+		"flag":  []string{"true", "false"},
+		"color": []string{"red", "red", "blue"},
+	}
+
+	var (
+		tags    []string
+		ids     []int
+		scores  []float64
+		flags   []bool
+		colors  []string
+		missing []int
+	)
+
+	err := Parse(data, Schema{
+		"tag":     Strings(&tags),
+		"id":      Ints(&ids, MinCount(1), MaxCount(3)),
+		"score":   Floats(&scores),
+		"flag":    Bools(&flags),
+		"color":   Strings(&colors, Unique(), MaxCount(2)),
+		"missing": IntsOr(&missing, []int{7}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []string{"a", "b", "a"}, tags)
+	must.Eq(t, []int{1, 2, 3}, ids)
+	must.Eq(t, []float64{1.5, 2.5}, scores)
+	must.Eq(t, []bool{true, false}, flags)
+	must.Eq(t, []string{"red", "blue"}, colors)
+	must.Eq(t, []int{7}, missing)
+}
+
+func Test_Parse_slices_Or(t *testing.T) {
+	var (
+		s []string
+		f []float64
+		b []bool
+	)
+
+	err := Parse(url.Values{"s": []string{"x"}}, Schema{
+		"s": StringsOr(&s, []string{"y"}),
+		"f": FloatsOr(&f, []float64{1.1}),
+		"b": BoolsOr(&b, []bool{true}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []string{"x"}, s)
+	must.Eq(t, []float64{1.1}, f)
+	must.Eq(t, []bool{true}, b)
+}
+
+func Test_Parse_slices_fail(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		parser func(*[]int) Parser
+		is     error
+	}{
+		{
+			name:   "missing",
+			values: nil,
+			parser: func(i *[]int) Parser { return Ints(i) },
+			is:     ErrNoValue,
+		},
+		{
+			name:   "malformed",
+			values: []string{"1", "x"},
+			parser: func(i *[]int) Parser { return Ints(i) },
+			is:     strconv.ErrSyntax,
+		},
+		{
+			name:   "too few",
+			values: []string{"1", "1"},
+			parser: func(i *[]int) Parser { return Ints(i, Unique(), MinCount(2)) },
+			is:     ErrTooFewValues,
+		},
+		{
+			name:   "too many",
+			values: []string{"1", "2", "3"},
+			parser: func(i *[]int) Parser { return Ints(i, MaxCount(2)) },
+			is:     ErrTooManyValues,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result []int
+			err := Parse(url.Values{"x": tc.values}, Schema{
+				"x": tc.parser(&result),
+			})
+			must.ErrorIs(t, err, tc.is)
+			must.Nil(t, result)
+		})
+	}
+}
@@ -3,7 +3,6 @@ module github.com/shoenig/extractors
 go 1.23
 
 require (
-	github.com/gorilla/mux v1.8.1
 	github.com/shoenig/go-conceal v0.5.4
 	github.com/shoenig/test v1.8.2
 )
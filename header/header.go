@@ -0,0 +1,278 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package header provides a way to safely and conveniently extract HTTP header
+// values using a defined schema.
+package header
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+)
+
+var (
+	ErrNoValue        = errors.New("expected header to exist")
+	ErrMultipleValues = errors.New("expected only one header value to exist")
+	ErrParseFailure   = errors.New("could not parse header")
+)
+
+// A Schema describes how a set of http.Header values should be parsed.
+//
+// Header names are canonicalized as with http.CanonicalHeaderKey, so names
+// such as "x-request-id" and "X-Request-Id" are equivalent.
+type Schema map[string]Parser
+
+// A Parser implementation is capable of extracting a value from the values of
+// an http.Header, which is a slice of string.
+type Parser interface {
+	Parse([]string) error
+}
+
+// Parse will parse the values in h given the header names and parsers defined
+// in schema.
+func Parse(h http.Header, schema Schema) error {
+	for _, name := range slices.Sorted(maps.Keys(schema)) {
+		key := http.CanonicalHeaderKey(name)
+		if err := schema[name].Parse(h.Values(key)); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrParseFailure, key, err)
+		}
+	}
+	return nil
+}
+
+// ParseRequest will parse the headers of r given the header names and parsers
+// defined in schema.
+func ParseRequest(r *http.Request, schema Schema) error {
+	return Parse(r.Header, schema)
+}
+
+// String is used to extract a header value into a Go string. If the header is
+// missing then an error is returned during parsing.
+func String(s *string) Parser {
+	return &stringParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+// StringOr is used to extract a header value into a Go string. If the header
+// is missing, then the alt value is used instead.
+func StringOr(s *string, alt string) Parser {
+	*s = alt
+	return &stringParser{
+		required:    false,
+		destination: s,
+	}
+}
+
+type stringParser struct {
+	required    bool
+	destination *string
+}
+
+func (p *stringParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMultipleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	*p.destination = values[0]
+	return nil
+}
+
+// Secret is used to extract a header value into a Go conceal.Text, e.g. the
+// Authorization header. If the header is missing then an error is returned
+// during parsing.
+func Secret(s **conceal.Text) Parser {
+	return &secretParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+type secretParser struct {
+	required    bool
+	destination **conceal.Text
+}
+
+func (p *secretParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMultipleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	*p.destination = conceal.New(values[0])
+	return nil
+}
+
+// Int is used to extract a header value into a Go int. If the value is not an
+// int or the header is missing then an error is returned during parsing.
+func Int(i *int) Parser {
+	return &intParser{
+		required:    true,
+		destination: i,
+	}
+}
+
+// IntOr is used to extract a header value into a Go int. If the header is
+// missing, then the alt value is used instead.
+func IntOr(i *int, alt int) Parser {
+	*i = alt
+	return &intParser{
+		required:    false,
+		destination: i,
+	}
+}
+
+type intParser struct {
+	required    bool
+	destination *int
+}
+
+func (p *intParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMultipleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(values[0]))
+	if err != nil {
+		return err
+	}
+
+	*p.destination = i
+	return nil
+}
+
+// Time is used to extract a header value into a Go time.Time, e.g. the
+// If-Modified-Since header. The value must be in one of the date formats
+// accepted by http.ParseTime. If the value is not a date or the header is
+// missing then an error is returned during parsing.
+func Time(t *time.Time) Parser {
+	return &timeParser{
+		required:    true,
+		destination: t,
+	}
+}
+
+// TimeOr is used to extract a header value into a Go time.Time. If the header
+// is missing, then the alt value is used instead.
+func TimeOr(t *time.Time, alt time.Time) Parser {
+	*t = alt
+	return &timeParser{
+		required:    false,
+		destination: t,
+	}
+}
+
+type timeParser struct {
+	required    bool
+	destination *time.Time
+}
+
+func (p *timeParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMultipleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	t, err := http.ParseTime(values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = t
+	return nil
+}
+
+// Strings is used to extract every value of a header that may be repeated into
+// a Go []string, one element per occurrence of the header. If the header is
+// missing then an error is returned during parsing.
+func Strings(s *[]string) Parser {
+	return &stringsParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+// List is used to extract a header containing a comma separated list of
+// elements, e.g. the Accept-Language header, into a Go []string. Repeated
+// occurrences of the header are combined, and whitespace around each element
+// is trimmed. If the header is missing then an error is returned during
+// parsing.
+//
+// Headers whose values may contain commas, such as dates, should not be parsed
+// as a List.
+func List(s *[]string) Parser {
+	return &stringsParser{
+		required:    true,
+		split:       true,
+		destination: s,
+	}
+}
+
+// ListOr is used to extract a header containing a comma separated list of
+// elements into a Go []string. If the header is missing, then the alt value
+// is used instead.
+func ListOr(s *[]string, alt []string) Parser {
+	*s = alt
+	return &stringsParser{
+		required:    false,
+		split:       true,
+		destination: s,
+	}
+}
+
+type stringsParser struct {
+	required    bool
+	split       bool
+	destination *[]string
+}
+
+func (p *stringsParser) Parse(values []string) error {
+	switch {
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	case !p.split:
+		*p.destination = slices.Clone(values)
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				result = append(result, element)
+			}
+		}
+	}
+
+	*p.destination = result
+	return nil
+}
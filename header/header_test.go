@@ -0,0 +1,109 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package header
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse(t *testing.T) {
+	h := make(http.Header)
+	h.Set("X-Request-ID", "abc123")
+	h.Set("Authorization", "Bearer hunter2")
+	h.Set("X-Tenant-Limit", "42")
+	h.Set("If-Modified-Since", "Mon, 06 May 2024 07:08:09 GMT")
+	h.Add("Accept-Language", "en-US, en;q=0.9")
+	h.Add("Accept-Language", "fr")
+	h.Add("X-Forwarded-For", "10.0.0.1")
+	h.Add("X-Forwarded-For", "10.0.0.2")
+
+	var (
+		requestID string
+		auth      *conceal.Text
+		limit     int
+		since     time.Time
+		languages []string
+		forwarded []string
+		ifMatch   string
+		retries   int
+		encodings []string
+	)
+
+	err := Parse(h, Schema{
+		"x-request-id":      String(&requestID),
+		"Authorization":     Secret(&auth),
+		"X-Tenant-Limit":    Int(&limit),
+		"If-Modified-Since": Time(&since),
+		"Accept-Language":   List(&languages),
+		"X-Forwarded-For":   Strings(&forwarded),
+		"If-Match":          StringOr(&ifMatch, "*"),
+		"X-Retries":         IntOr(&retries, 3),
+		"Accept-Encoding":   ListOr(&encodings, []string{"identity"}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "abc123", requestID)
+	must.Eq(t, "Bearer hunter2", auth.Unveil())
+	must.Eq(t, 42, limit)
+	must.Eq(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), since)
+	must.Eq(t, []string{"en-US", "en;q=0.9", "fr"}, languages)
+	must.Eq(t, []string{"10.0.0.1", "10.0.0.2"}, forwarded)
+	must.Eq(t, "*", ifMatch)
+	must.Eq(t, 3, retries)
+	must.Eq(t, []string{"identity"}, encodings)
+}
+
+func Test_ParseRequest(t *testing.T) {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	must.NoError(t, err)
+	request.Header.Set("X-Trace", "t1")
+
+	var trace string
+	err = ParseRequest(request, Schema{
+		"X-Trace": String(&trace),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "t1", trace)
+}
+
+func Test_Parse_fail(t *testing.T) {
+	h := make(http.Header)
+	h.Set("X-Limit", "lots")
+	h.Set("Date", "yesterday")
+	h.Add("X-Request-Id", "a")
+	h.Add("X-Request-Id", "b")
+
+	var limit int
+	err := Parse(h, Schema{
+		"x-limit": Int(&limit),
+	})
+	must.ErrorIs(t, err, ErrParseFailure)
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.ErrorContains(t, err, `"X-Limit"`)
+
+	var date time.Time
+	err = Parse(h, Schema{
+		"Date": Time(&date),
+	})
+	must.ErrorIs(t, err, ErrParseFailure)
+
+	var id string
+	err = Parse(h, Schema{
+		"X-Request-Id": String(&id),
+	})
+	must.ErrorIs(t, err, ErrMultipleValues)
+
+	var missing []string
+	err = Parse(h, Schema{
+		"X-Missing": List(&missing),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package boolean provides parsing of strings into bools using a configurable
+// vocabulary, for use by the bool parsers of each extractor package.
+package boolean
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Vocabulary is the set of values recognized as true and as false. Values
+// are matched case-insensitively, after trimming leading and trailing
+// whitespace.
+type Vocabulary struct {
+	True  []string
+	False []string
+}
+
+// Parse returns whether s is one of the True or False values of v. If s is
+// neither, an error wrapping strconv.ErrSyntax is returned.
+func (v Vocabulary) Parse(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	for _, t := range v.True {
+		if strings.EqualFold(s, t) {
+			return true, nil
+		}
+	}
+	for _, f := range v.False {
+		if strings.EqualFold(s, f) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("expected one of %s: %w", v.expected(), strconv.ErrSyntax)
+}
+
+func (v Vocabulary) expected() string {
+	quoted := make([]string, 0, len(v.True)+len(v.False))
+	for _, s := range v.True {
+		quoted = append(quoted, strconv.Quote(s))
+	}
+	for _, s := range v.False {
+		quoted = append(quoted, strconv.Quote(s))
+	}
+	return strings.Join(quoted, ", ")
+}
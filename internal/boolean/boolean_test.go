@@ -0,0 +1,48 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package boolean
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Vocabulary_Parse(t *testing.T) {
+	v := Vocabulary{
+		True:  []string{"on", "yes"},
+		False: []string{"off", "no"},
+	}
+
+	cases := []struct {
+		value string
+		exp   bool
+	}{
+		{value: "on", exp: true},
+		{value: "YES", exp: true},
+		{value: " yes ", exp: true},
+		{value: "off", exp: false},
+		{value: "No", exp: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			result, err := v.Parse(tc.value)
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, result)
+		})
+	}
+}
+
+func Test_Vocabulary_Parse_fail(t *testing.T) {
+	v := Vocabulary{
+		True:  []string{"on"},
+		False: []string{"off"},
+	}
+
+	_, err := v.Parse("true")
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.EqError(t, err, `expected one of "on", "off": invalid syntax`)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package ids provides parsing of strings into UUID, ULID, and KSUID
+// identifiers, for use by the identifier parsers of each extractor package.
+package ids
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+)
+
+// ParseUUID parses s as a UUID in the canonical 8-4-4-4-12 hexadecimal form
+// described by RFC 9562, e.g. 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+//
+// The UUID must be the Nil UUID, the Max UUID, or have the RFC 9562 variant
+// and a version between 1 and 8. If versions are given, then the UUID must be
+// one of those versions, and the Nil and Max UUIDs are not accepted.
+//
+// Any error wraps strconv.ErrSyntax.
+func ParseUUID[T ~[16]byte](s string, versions ...int) (T, error) {
+	var u [16]byte
+
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return T(u), syntaxError(s, "UUID")
+	}
+
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return T(u), syntaxError(s, "UUID")
+	}
+
+	special := u == [16]byte{} || u == [16]byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+
+	version := int(u[6] >> 4)
+	variant := u[8] >> 6
+
+	switch {
+	case special && len(versions) == 0:
+		return T(u), nil
+	case special:
+		return T(u), invalidUUID(s, "not one of versions %v", versions)
+	case variant != 0b10:
+		return T(u), invalidUUID(s, "not the RFC 9562 variant")
+	case version < 1 || version > 8:
+		return T(u), invalidUUID(s, "unknown version %d", version)
+	case len(versions) > 0 && !slices.Contains(versions, version):
+		return T(u), invalidUUID(s, "version %d is not one of versions %v", version, versions)
+	}
+
+	return T(u), nil
+}
+
+// UUIDFunc returns a function that parses a string as a UUID of the given
+// versions using ParseUUID, for use as the conversion function of a parser.
+func UUIDFunc[T ~[16]byte](versions ...int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		return ParseUUID[T](s, versions...)
+	}
+}
+
+// crockford is the alphabet of the Crockford base32 encoding used by ULID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ParseULID parses s as a ULID, which is 26 characters of Crockford base32
+// encoding a 48 bit timestamp and 80 bits of randomness, e.g.
+// 01ARZ3NDEKTSV4RRFFQ69G5FAV. Letters are accepted in either case.
+func ParseULID[T ~[16]byte](s string) (T, error) {
+	var u T
+
+	if len(s) != 26 {
+		return u, syntaxError(s, "ULID")
+	}
+
+	// the first character encodes only the top 3 bits of the 128 bit value
+	if s[0] > '7' {
+		return u, fmt.Errorf("unable to parse %q as ULID: %w", s, strconv.ErrRange)
+	}
+
+	value := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := decodeCrockford(s[i])
+		if d < 0 {
+			return u, syntaxError(s, "ULID")
+		}
+		value.Lsh(value, 5)
+		value.Or(value, big.NewInt(int64(d)))
+	}
+
+	value.FillBytes(u[:])
+	return u, nil
+}
+
+func decodeCrockford(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	for i := 0; i < len(crockford); i++ {
+		if crockford[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseKSUID parses s as a KSUID, which is 27 characters of base62 encoding a
+// 32 bit timestamp and 128 bits of randomness, e.g.
+// 0ujtsYcgvSTl8PAuAdqWYSMnLOv.
+func ParseKSUID[T ~[20]byte](s string) (T, error) {
+	var k T
+
+	if len(s) != 27 {
+		return k, syntaxError(s, "KSUID")
+	}
+
+	value := new(big.Int)
+	radix := big.NewInt(62)
+	for i := 0; i < len(s); i++ {
+		d := decodeBase62(s[i])
+		if d < 0 {
+			return k, syntaxError(s, "KSUID")
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(d)))
+	}
+
+	if value.BitLen() > 160 {
+		return k, fmt.Errorf("unable to parse %q as KSUID: %w", s, strconv.ErrRange)
+	}
+
+	value.FillBytes(k[:])
+	return k, nil
+}
+
+func decodeBase62(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 36
+	}
+	return -1
+}
+
+func syntaxError(s, kind string) error {
+	return fmt.Errorf("unable to parse %q as %s: %w", s, kind, strconv.ErrSyntax)
+}
+
+// invalidUUID returns an error wrapping strconv.ErrSyntax for a UUID that is
+// well formed but not valid, explaining why with format and args.
+func invalidUUID(s, format string, args ...any) error {
+	return fmt.Errorf("unable to parse %q as UUID: %w: "+format, append([]any{s, strconv.ErrSyntax}, args...)...)
+}
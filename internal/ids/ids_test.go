@@ -0,0 +1,142 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ids
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func decode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	must.NoError(t, err)
+	return b
+}
+
+func Test_ParseUUID(t *testing.T) {
+	cases := []struct {
+		value    string
+		versions []int
+		exp      string
+	}{
+		{value: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", exp: "6ba7b8109dad11d180b400c04fd430c8"},
+		{value: "F47AC10B-58CC-4372-A567-0E02B2C3D479", exp: "f47ac10b58cc4372a5670e02b2c3d479"},
+		{value: "f47ac10b-58cc-4372-a567-0e02b2c3d479", versions: []int{4, 7}, exp: "f47ac10b58cc4372a5670e02b2c3d479"},
+		{value: "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", versions: []int{7}, exp: "017f22e279b07cc398c4dc0c0c07398f"},
+		{value: "00000000-0000-0000-0000-000000000000", exp: "00000000000000000000000000000000"},
+		{value: "ffffffff-ffff-ffff-ffff-ffffffffffff", exp: "ffffffffffffffffffffffffffffffff"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			u, err := ParseUUID[[16]byte](tc.value, tc.versions...)
+			must.NoError(t, err)
+			must.Eq(t, decode(t, tc.exp), u[:])
+		})
+	}
+}
+
+func Test_ParseUUID_fail(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		versions []int
+		exp      string
+	}{
+		{
+			name:  "empty",
+			value: "",
+			exp:   `unable to parse "" as UUID: invalid syntax`,
+		},
+		{
+			name:  "no hyphens",
+			value: "f47ac10b58cc4372a5670e02b2c3d479",
+			exp:   `unable to parse "f47ac10b58cc4372a5670e02b2c3d479" as UUID: invalid syntax`,
+		},
+		{
+			name:  "not hex",
+			value: "g47ac10b-58cc-4372-a567-0e02b2c3d479",
+			exp:   `unable to parse "g47ac10b-58cc-4372-a567-0e02b2c3d479" as UUID: invalid syntax`,
+		},
+		{
+			name:  "variant",
+			value: "f47ac10b-58cc-4372-c567-0e02b2c3d479",
+			exp:   `unable to parse "f47ac10b-58cc-4372-c567-0e02b2c3d479" as UUID: invalid syntax: not the RFC 9562 variant`,
+		},
+		{
+			name:  "unknown version",
+			value: "f47ac10b-58cc-9372-a567-0e02b2c3d479",
+			exp:   `unable to parse "f47ac10b-58cc-9372-a567-0e02b2c3d479" as UUID: invalid syntax: unknown version 9`,
+		},
+		{
+			name:     "wrong version",
+			value:    "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			versions: []int{7},
+			exp:      `unable to parse "f47ac10b-58cc-4372-a567-0e02b2c3d479" as UUID: invalid syntax: version 4 is not one of versions [7]`,
+		},
+		{
+			name:     "nil with versions",
+			value:    "00000000-0000-0000-0000-000000000000",
+			versions: []int{4},
+			exp:      `unable to parse "00000000-0000-0000-0000-000000000000" as UUID: invalid syntax: not one of versions [4]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseUUID[[16]byte](tc.value, tc.versions...)
+			must.EqError(t, err, tc.exp)
+			must.ErrorIs(t, err, strconv.ErrSyntax)
+		})
+	}
+}
+
+func Test_ParseULID(t *testing.T) {
+	u, err := ParseULID[[16]byte]("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	must.NoError(t, err)
+	must.Eq(t, decode(t, "01563e3ab5d3d6764c61efb99302bd5b"), u[:])
+
+	lower, err := ParseULID[[16]byte]("01arz3ndektsv4rrffq69g5fav")
+	must.NoError(t, err)
+	must.Eq(t, u, lower)
+
+	u, err = ParseULID[[16]byte]("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	must.NoError(t, err)
+	must.Eq(t, decode(t, "ffffffffffffffffffffffffffffffff"), u[:])
+}
+
+func Test_ParseULID_fail(t *testing.T) {
+	_, err := ParseULID[[16]byte]("01ARZ3NDEKTSV4RRFFQ69G5FA")
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = ParseULID[[16]byte]("01ARZ3NDEKTSV4RRFFQ69G5FAU")
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = ParseULID[[16]byte]("8ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	must.ErrorIs(t, err, strconv.ErrRange)
+}
+
+func Test_ParseKSUID(t *testing.T) {
+	k, err := ParseKSUID[[20]byte]("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	must.NoError(t, err)
+	must.Eq(t, decode(t, "0669f7efb5a1cd34b5f99d1154fb6853345c9735"), k[:])
+
+	k, err = ParseKSUID[[20]byte]("aWgEPTl1tmebfsQzFP4bxwgy80V")
+	must.NoError(t, err)
+	must.Eq(t, decode(t, "ffffffffffffffffffffffffffffffffffffffff"), k[:])
+}
+
+func Test_ParseKSUID_fail(t *testing.T) {
+	_, err := ParseKSUID[[20]byte]("0ujtsYcgvSTl8PAuAdqWYSMnLO")
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = ParseKSUID[[20]byte]("0ujtsYcgvSTl8PAuAdqWYSMnLO-")
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = ParseKSUID[[20]byte]("aWgEPTl1tmebfsQzFP4bxwgy80W")
+	must.ErrorIs(t, err, strconv.ErrRange)
+}
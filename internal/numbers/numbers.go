@@ -0,0 +1,62 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package numbers provides parsing of strings into sized numeric types, for
+// use by the generic numeric parsers of each extractor package.
+package numbers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Integer is the set of integer types that can be parsed.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the set of floating point types that can be parsed.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of all numeric types that can be parsed.
+type Number interface {
+	Integer | Float
+}
+
+// ParseInteger parses s as a base 10 integer of type T. If s represents a
+// value that does not fit in T, the returned error wraps strconv.ErrRange.
+func ParseInteger[T Integer](s string) (T, error) {
+	var zero T
+	bits := reflect.TypeFor[T]().Bits()
+
+	// the zero value minus one wraps around for unsigned types
+	if zero-1 > 0 {
+		u, err := strconv.ParseUint(s, 10, bits)
+		return T(u), err
+	}
+
+	i, err := strconv.ParseInt(s, 10, bits)
+	return T(i), err
+}
+
+// ParseFloat parses s as a floating point number of type T. If s represents
+// a value that does not fit in T, the returned error wraps strconv.ErrRange.
+func ParseFloat[T Float](s string) (T, error) {
+	bits := reflect.TypeFor[T]().Bits()
+
+	f, err := strconv.ParseFloat(s, bits)
+	return T(f), err
+}
+
+// CheckRange returns an error wrapping strconv.ErrRange if value is not
+// within the inclusive range of lo to hi. NaN is never within range.
+func CheckRange[T Number](value, lo, hi T) error {
+	if !(value >= lo && value <= hi) {
+		return fmt.Errorf("%w: %v is not between %v and %v", strconv.ErrRange, value, lo, hi)
+	}
+	return nil
+}
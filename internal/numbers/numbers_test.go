@@ -0,0 +1,63 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package numbers
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_ParseInteger(t *testing.T) {
+	i8, err := ParseInteger[int8]("-128")
+	must.NoError(t, err)
+	must.Eq(t, math.MinInt8, i8)
+
+	_, err = ParseInteger[int8]("128")
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	u16, err := ParseInteger[uint16]("65535")
+	must.NoError(t, err)
+	must.Eq(t, math.MaxUint16, u16)
+
+	_, err = ParseInteger[uint16]("65536")
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = ParseInteger[uint]("-1")
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	u64, err := ParseInteger[uint64]("18446744073709551615")
+	must.NoError(t, err)
+	must.Eq(t, uint64(math.MaxUint64), u64)
+
+	type port uint16
+	p, err := ParseInteger[port]("8080")
+	must.NoError(t, err)
+	must.Eq(t, 8080, p)
+}
+
+func Test_ParseFloat(t *testing.T) {
+	f32, err := ParseFloat[float32]("1.5")
+	must.NoError(t, err)
+	must.Eq(t, 1.5, f32)
+
+	_, err = ParseFloat[float32]("1e39")
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	f64, err := ParseFloat[float64]("1e39")
+	must.NoError(t, err)
+	must.Eq(t, 1e39, f64)
+}
+
+func Test_CheckRange(t *testing.T) {
+	must.NoError(t, CheckRange(1, 1, 10))
+	must.NoError(t, CheckRange(10, 1, 10))
+	must.ErrorIs(t, CheckRange(0, 1, 10), strconv.ErrRange)
+	must.EqError(t, CheckRange(11, 1, 10), "value out of range: 11 is not between 1 and 10")
+	must.NoError(t, CheckRange(0.5, 0.0, 1.0))
+	must.ErrorIs(t, CheckRange(math.NaN(), 0.0, 1.0), strconv.ErrRange)
+	must.ErrorIs(t, CheckRange(float32(math.NaN()), 0, 1), strconv.ErrRange)
+}
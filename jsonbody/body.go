@@ -0,0 +1,583 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package jsonbody provides a way to safely and conveniently extract values
+// from a JSON request body using a defined schema, without declaring a struct
+// type for each request.
+//
+// Values are located using JSON Pointer (RFC 6901) paths.
+//
+//	var (
+//	    name string
+//	    id   int
+//	)
+//	err := jsonbody.Parse(request, jsonbody.Schema{
+//	    "/user/name": jsonbody.String(&name),
+//	    "/items/0/id": jsonbody.Int(&id),
+//	})
+package jsonbody
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shoenig/extractors/internal/numbers"
+	"github.com/shoenig/go-conceal"
+)
+
+var (
+	ErrNoValue        = errors.New("expected value to exist")
+	ErrWrongType      = errors.New("value is of the wrong type")
+	ErrParseFailure   = errors.New("could not parse value")
+	ErrUnknownField   = errors.New("unknown field")
+	ErrTooLarge       = errors.New("request body is too large")
+	ErrInvalidPointer = errors.New("invalid json pointer")
+)
+
+// DefaultMaxBytes is the maximum size of a request body read by Parse, unless
+// configured otherwise using MaxBytes.
+const DefaultMaxBytes = 1 << 20
+
+// A Schema describes how values in a JSON document should be parsed, keyed by
+// the JSON Pointer path of each value, e.g. "/user/name" or "/items/0/id".
+type Schema map[string]Parser
+
+// A Parser implementation is capable of extracting a value from a decoded
+// JSON value, which is one of nil, bool, json.Number, string, []any, or
+// map[string]any. If the value does not exist in the document, Parse is called
+// with exists set to false.
+type Parser interface {
+	Parse(value any, exists bool) error
+}
+
+// An Option configures how a JSON document is parsed.
+type Option func(*options)
+
+type options struct {
+	maxBytes        int64
+	disallowUnknown bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{maxBytes: DefaultMaxBytes}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// MaxBytes sets the maximum size of a request body read by Parse. A larger
+// body results in an error wrapping ErrTooLarge.
+func MaxBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// DisallowUnknownFields causes an error wrapping ErrUnknownField if the
+// document contains any value not described by the Schema.
+func DisallowUnknownFields() Option {
+	return func(o *options) {
+		o.disallowUnknown = true
+	}
+}
+
+// Parse will read and parse the JSON body of r given the JSON pointers and
+// parsers defined in schema. At most DefaultMaxBytes of the body are read,
+// unless configured otherwise using MaxBytes.
+func Parse(r *http.Request, schema Schema, opts ...Option) error {
+	o := newOptions(opts)
+
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(nil, body, o.maxBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, tooLarge.Limit)
+		}
+		return err
+	}
+
+	return parse(data, schema, o)
+}
+
+// ParseBytes will parse the JSON document in data given the JSON pointers and
+// parsers defined in schema.
+func ParseBytes(data []byte, schema Schema, opts ...Option) error {
+	return parse(data, schema, newOptions(opts))
+}
+
+func parse(data []byte, schema Schema, o options) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return fmt.Errorf("%w: %w", ErrParseFailure, err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected data after json document", ErrParseFailure)
+	}
+
+	if o.disallowUnknown {
+		if err := unknown(document, "", schema); err != nil {
+			return err
+		}
+	}
+
+	for _, pointer := range slices.Sorted(maps.Keys(schema)) {
+		value, exists, err := resolve(document, pointer)
+		if err != nil {
+			return err
+		}
+
+		if err = schema[pointer].Parse(value, exists); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrParseFailure, pointer, err)
+		}
+	}
+	return nil
+}
+
+// resolve returns the value in document referred to by pointer.
+func resolve(document any, pointer string) (any, bool, error) {
+	if pointer == "" {
+		return document, true, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false, fmt.Errorf("%w: %q must begin with '/'", ErrInvalidPointer, pointer)
+	}
+
+	current := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch node := current.(type) {
+		case map[string]any:
+			value, exists := node[token]
+			if !exists {
+				return nil, false, nil
+			}
+			current = value
+		case []any:
+			index, ok := arrayIndex(token)
+			if !ok || index >= len(node) {
+				return nil, false, nil
+			}
+			current = node[index]
+		default:
+			return nil, false, nil
+		}
+	}
+	return current, true, nil
+}
+
+func arrayIndex(token string) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	index, err := strconv.Atoi(token)
+	return index, err == nil
+}
+
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// unknown returns an error describing each value in node that is not
+// described by the schema. An object or array containing no value described by
+// the schema is itself unknown, even if it is empty. The document itself is
+// never unknown.
+func unknown(node any, pointer string, schema Schema) error {
+	if _, exists := schema[pointer]; exists {
+		return nil
+	}
+
+	if pointer != "" && !contains(schema, pointer) {
+		return fmt.Errorf("%w: %q", ErrUnknownField, pointer)
+	}
+
+	var errs []error
+	switch n := node.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(n)) {
+			if err := unknown(n[key], pointer+"/"+escape(key), schema); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	case []any:
+		for i, element := range n {
+			if err := unknown(element, pointer+"/"+strconv.Itoa(i), schema); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	default:
+		if pointer == "" {
+			return nil
+		}
+		return fmt.Errorf("%w: %q", ErrUnknownField, pointer)
+	}
+	return errors.Join(errs...)
+}
+
+// contains returns whether schema describes any value within the object or
+// array located by pointer.
+func contains(schema Schema, pointer string) bool {
+	for key := range schema {
+		if strings.HasPrefix(key, pointer+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func wrongType(expected string, value any) error {
+	return fmt.Errorf("%w: expected %s, got %s", ErrWrongType, expected, typeName(value))
+}
+
+// missing reports whether a value should be treated as not existing, which
+// includes a value that is explicitly null.
+func missing(value any, exists bool) bool {
+	return !exists || value == nil
+}
+
+// String is used to extract a JSON string into a Go string. If the value is
+// not a string or is missing then an error is returned during parsing.
+func String(s *string) Parser {
+	return &stringParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+// StringOr is used to extract a JSON string into a Go string. If the value is
+// missing or null, then the alt value is used instead.
+func StringOr(s *string, alt string) Parser {
+	*s = alt
+	return &stringParser{
+		required:    false,
+		destination: s,
+	}
+}
+
+type stringParser struct {
+	required    bool
+	destination *string
+}
+
+func (p *stringParser) Parse(value any, exists bool) error {
+	switch {
+	case missing(value, exists) && p.required:
+		return ErrNoValue
+	case missing(value, exists):
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return wrongType("string", value)
+	}
+
+	*p.destination = s
+	return nil
+}
+
+// Secret is used to extract a JSON string into a Go conceal.Text. If the value
+// is not a string or is missing then an error is returned during parsing.
+func Secret(s **conceal.Text) Parser {
+	return &secretParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+type secretParser struct {
+	required    bool
+	destination **conceal.Text
+}
+
+func (p *secretParser) Parse(value any, exists bool) error {
+	switch {
+	case missing(value, exists) && p.required:
+		return ErrNoValue
+	case missing(value, exists):
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return wrongType("string", value)
+	}
+
+	*p.destination = conceal.New(s)
+	return nil
+}
+
+// Int is used to extract a JSON number into a Go int. If the value is not an
+// integer or is missing then an error is returned during parsing.
+func Int(i *int) Parser {
+	return Integer(i)
+}
+
+// IntOr is used to extract a JSON number into a Go int. If the value is
+// missing or null, then the alt value is used instead.
+func IntOr(i *int, alt int) Parser {
+	return IntegerOr(i, alt)
+}
+
+// Integer is used to extract a JSON number into any Go integer type. If the
+// value is not an integer, does not fit in the type, or is missing then an
+// error is returned during parsing.
+func Integer[T numbers.Integer](i *T) Parser {
+	return &numberParser[T]{
+		required:    true,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// IntegerOr is used to extract a JSON number into any Go integer type. If the
+// value is missing or null, then the alt value is used instead.
+func IntegerOr[T numbers.Integer](i *T, alt T) Parser {
+	*i = alt
+	return &numberParser[T]{
+		required:    false,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// Float is used to extract a JSON number into a Go float64. If the value is
+// not a number or is missing then an error is returned during parsing.
+func Float(f *float64) Parser {
+	return &numberParser[float64]{
+		required:    true,
+		convert:     numbers.ParseFloat[float64],
+		destination: f,
+	}
+}
+
+// FloatOr is used to extract a JSON number into a Go float64. If the value is
+// missing or null, then the alt value is used instead.
+func FloatOr(f *float64, alt float64) Parser {
+	*f = alt
+	return &numberParser[float64]{
+		required:    false,
+		convert:     numbers.ParseFloat[float64],
+		destination: f,
+	}
+}
+
+type numberParser[T numbers.Number] struct {
+	required    bool
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (p *numberParser[T]) Parse(value any, exists bool) error {
+	switch {
+	case missing(value, exists) && p.required:
+		return ErrNoValue
+	case missing(value, exists):
+		return nil
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return wrongType("number", value)
+	}
+
+	n, err := p.convert(number.String())
+	if err != nil {
+		return err
+	}
+
+	*p.destination = n
+	return nil
+}
+
+// Bool is used to extract a JSON boolean into a Go bool. If the value is not a
+// boolean or is missing then an error is returned during parsing.
+func Bool(b *bool) Parser {
+	return &boolParser{
+		required:    true,
+		destination: b,
+	}
+}
+
+// BoolOr is used to extract a JSON boolean into a Go bool. If the value is
+// missing or null, then the alt value is used instead.
+func BoolOr(b *bool, alt bool) Parser {
+	*b = alt
+	return &boolParser{
+		required:    false,
+		destination: b,
+	}
+}
+
+type boolParser struct {
+	required    bool
+	destination *bool
+}
+
+func (p *boolParser) Parse(value any, exists bool) error {
+	switch {
+	case missing(value, exists) && p.required:
+		return ErrNoValue
+	case missing(value, exists):
+		return nil
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return wrongType("boolean", value)
+	}
+
+	*p.destination = b
+	return nil
+}
+
+// Time is used to extract a JSON string into a Go time.Time, using the given
+// layout as accepted by time.Parse. If layout is empty, then time.RFC3339 is
+// used. If the value is not a time in the layout or is missing then an error
+// is returned during parsing.
+func Time(t *time.Time, layout string) Parser {
+	return &timeParser{
+		required:    true,
+		layout:      timeLayout(layout),
+		destination: t,
+	}
+}
+
+// TimeOr is used to extract a JSON string into a Go time.Time, using the given
+// layout as accepted by time.Parse. If the value is missing or null, then the
+// alt value is used instead.
+func TimeOr(t *time.Time, layout string, alt time.Time) Parser {
+	*t = alt
+	return &timeParser{
+		required:    false,
+		layout:      timeLayout(layout),
+		destination: t,
+	}
+}
+
+func timeLayout(layout string) string {
+	if layout == "" {
+		return time.RFC3339
+	}
+	return layout
+}
+
+type timeParser struct {
+	required    bool
+	layout      string
+	destination *time.Time
+}
+
+func (p *timeParser) Parse(value any, exists bool) error {
+	switch {
+	case missing(value, exists) && p.required:
+		return ErrNoValue
+	case missing(value, exists):
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return wrongType("string", value)
+	}
+
+	t, err := time.Parse(p.layout, s)
+	if err != nil {
+		return err
+	}
+
+	*p.destination = t
+	return nil
+}
+
+// Strings is used to extract a JSON array of strings into a Go []string. If
+// the value is not an array of strings or is missing then an error is returned
+// during parsing.
+func Strings(s *[]string) Parser {
+	return &stringsParser{
+		required:    true,
+		destination: s,
+	}
+}
+
+// StringsOr is used to extract a JSON array of strings into a Go []string. If
+// the value is missing or null, then the alt value is used instead.
+func StringsOr(s *[]string, alt []string) Parser {
+	*s = alt
+	return &stringsParser{
+		required:    false,
+		destination: s,
+	}
+}
+
+type stringsParser struct {
+	required    bool
+	destination *[]string
+}
+
+func (p *stringsParser) Parse(value any, exists bool) error {
+	switch {
+	case missing(value, exists) && p.required:
+		return ErrNoValue
+	case missing(value, exists):
+		return nil
+	}
+
+	elements, ok := value.([]any)
+	if !ok {
+		return wrongType("array", value)
+	}
+
+	result := make([]string, 0, len(elements))
+	for i, element := range elements {
+		s, ok := element.(string)
+		if !ok {
+			return fmt.Errorf("element %d: %w", i, wrongType("string", element))
+		}
+		result = append(result, s)
+	}
+
+	*p.destination = result
+	return nil
+}
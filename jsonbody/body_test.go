@@ -0,0 +1,267 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package jsonbody
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+const document = `{
+  "user": {
+    "name": "bob",
+    "age": 45,
+    "admin": true,
+    "password": "hunter2",
+    "joined": "2024-05-06T07:08:09Z"
+  },
+  "items": [
+    {"id": 1, "price": 9.99},
+    {"id": 2, "price": 19.99}
+  ],
+  "tags": ["a", "b"],
+  "a/b": "slash",
+  "m~n": "tilde",
+  "nothing": null
+}`
+
+func newRequest(t *testing.T, body string) *http.Request {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader(body))
+	must.NoError(t, err)
+	return request
+}
+
+func Test_Parse(t *testing.T) {
+	var (
+		name     string
+		age      uint8
+		admin    bool
+		password *conceal.Text
+		joined   time.Time
+		id       int
+		price    float64
+		tags     []string
+		slash    string
+		tilde    string
+		nothing  string
+		missing  int
+	)
+
+	err := Parse(newRequest(t, document), Schema{
+		"/user/name":     String(&name),
+		"/user/age":      Integer(&age),
+		"/user/admin":    Bool(&admin),
+		"/user/password": Secret(&password),
+		"/user/joined":   Time(&joined, ""),
+		"/items/1/id":    Int(&id),
+		"/items/0/price": Float(&price),
+		"/tags":          Strings(&tags),
+		"/a~1b":          String(&slash),
+		"/m~0n":          String(&tilde),
+		"/nothing":       StringOr(&nothing, "default"),
+		"/missing":       IntOr(&missing, 7),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, 45, age)
+	must.True(t, admin)
+	must.Eq(t, "hunter2", password.Unveil())
+	must.Eq(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), joined)
+	must.Eq(t, 2, id)
+	must.Eq(t, 9.99, price)
+	must.Eq(t, []string{"a", "b"}, tags)
+	must.Eq(t, "slash", slash)
+	must.Eq(t, "tilde", tilde)
+	must.Eq(t, "default", nothing)
+	must.Eq(t, 7, missing)
+}
+
+func Test_ParseBytes_fail(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema func() Schema
+		is     error
+		msg    string
+	}{
+		{
+			name: "missing",
+			schema: func() Schema {
+				var s string
+				return Schema{"/user/email": String(&s)}
+			},
+			is:  ErrNoValue,
+			msg: `could not parse value: "/user/email": expected value to exist`,
+		},
+		{
+			name: "null",
+			schema: func() Schema {
+				var s string
+				return Schema{"/nothing": String(&s)}
+			},
+			is: ErrNoValue,
+		},
+		{
+			name: "out of range index",
+			schema: func() Schema {
+				var i int
+				return Schema{"/items/2/id": Int(&i)}
+			},
+			is: ErrNoValue,
+		},
+		{
+			name: "wrong type",
+			schema: func() Schema {
+				var s string
+				return Schema{"/user/age": String(&s)}
+			},
+			is:  ErrWrongType,
+			msg: `could not parse value: "/user/age": value is of the wrong type: expected string, got number`,
+		},
+		{
+			name: "float as integer",
+			schema: func() Schema {
+				var i int8
+				return Schema{"/items/1/price": IntegerOr(&i, 0)}
+			},
+			is: strconv.ErrSyntax,
+		},
+		{
+			name: "array element",
+			schema: func() Schema {
+				var s []string
+				return Schema{"/items": Strings(&s)}
+			},
+			is:  ErrWrongType,
+			msg: `could not parse value: "/items": element 0: value is of the wrong type: expected string, got object`,
+		},
+		{
+			name: "invalid pointer",
+			schema: func() Schema {
+				var s string
+				return Schema{"user/name": String(&s)}
+			},
+			is: ErrInvalidPointer,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ParseBytes([]byte(document), tc.schema())
+			must.ErrorIs(t, err, tc.is)
+			if tc.msg != "" {
+				must.EqError(t, err, tc.msg)
+			}
+		})
+	}
+}
+
+func Test_ParseBytes_overflow(t *testing.T) {
+	var i int8
+	err := ParseBytes([]byte(`{"n": 300}`), Schema{"/n": Integer(&i)})
+	must.ErrorIs(t, err, strconv.ErrRange)
+}
+
+func Test_ParseBytes_malformed(t *testing.T) {
+	var s string
+	err := ParseBytes([]byte(`{"a": `), Schema{"/a": String(&s)})
+	must.ErrorIs(t, err, ErrParseFailure)
+
+	err = ParseBytes([]byte(`{"a": "b"} {"c": "d"}`), Schema{"/a": String(&s)})
+	must.ErrorIs(t, err, ErrParseFailure)
+}
+
+func Test_Parse_MaxBytes(t *testing.T) {
+	var name string
+	err := Parse(newRequest(t, document), Schema{
+		"/user/name": String(&name),
+	}, MaxBytes(16))
+	must.ErrorIs(t, err, ErrTooLarge)
+	must.Eq(t, "", name)
+}
+
+func Test_Parse_DisallowUnknownFields(t *testing.T) {
+	body := `{"name": "bob", "address": {"city": "Austin", "zip": "78701"}, "tags": ["a"]}`
+
+	var (
+		name string
+		city string
+		tags []string
+	)
+
+	err := Parse(newRequest(t, body), Schema{
+		"/name":         String(&name),
+		"/address/city": String(&city),
+		"/tags":         Strings(&tags),
+	}, DisallowUnknownFields())
+	must.ErrorIs(t, err, ErrUnknownField)
+	must.EqError(t, err, `unknown field: "/address/zip"`)
+
+	var zip string
+	err = Parse(newRequest(t, body), Schema{
+		"/name":         String(&name),
+		"/address/city": String(&city),
+		"/address/zip":  String(&zip),
+		"/tags":         Strings(&tags),
+	}, DisallowUnknownFields())
+	must.NoError(t, err)
+	must.Eq(t, "78701", zip)
+}
+
+func Test_ParseBytes_DisallowUnknownFields_containers(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		exp  string
+	}{
+		{
+			name: "empty object",
+			body: `{"name": "x", "extra": {}}`,
+			exp:  `unknown field: "/extra"`,
+		},
+		{
+			name: "empty array",
+			body: `{"name": "x", "extra": []}`,
+			exp:  `unknown field: "/extra"`,
+		},
+		{
+			name: "nested object",
+			body: `{"name": "x", "extra": {"a": {"b": 1}}}`,
+			exp:  `unknown field: "/extra"`,
+		},
+		{
+			name: "leaf",
+			body: `{"name": "x", "extra": 1}`,
+			exp:  `unknown field: "/extra"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var name string
+			err := ParseBytes([]byte(tc.body), Schema{
+				"/name": String(&name),
+			}, DisallowUnknownFields())
+			must.ErrorIs(t, err, ErrUnknownField)
+			must.EqError(t, err, tc.exp)
+		})
+	}
+
+	err := ParseBytes([]byte(`{}`), Schema{}, DisallowUnknownFields())
+	must.NoError(t, err)
+
+	var a string
+	err = ParseBytes([]byte(`5`), Schema{
+		"/a": StringOr(&a, "x"),
+	}, DisallowUnknownFields())
+	must.NoError(t, err)
+	must.Eq(t, "x", a)
+}
@@ -0,0 +1,257 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package query provides a way to safely and conveniently extract URL query
+// parameters using a defined schema.
+//
+// Unlike the formdata package, which extracts values from the combination of
+// the query string and the request body, only values from the query string of
+// the request URL are considered.
+package query
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/shoenig/extractors/formdata"
+	"github.com/shoenig/extractors/internal/numbers"
+	"github.com/shoenig/go-conceal"
+)
+
+var (
+	ErrNoValue        = formdata.ErrNoValue
+	ErrMultipleValues = formdata.ErrMulitpleValues
+	ErrParseFailure   = formdata.ErrParseFailure
+	ErrTooFewValues   = formdata.ErrTooFewValues
+	ErrTooManyValues  = formdata.ErrTooManyValues
+)
+
+// A Schema describes how a set of query parameters should be parsed.
+type Schema map[string]Parser
+
+// A Parser implementation is capable of extracting a value from the values of
+// a query parameter, which is a slice of string.
+type Parser interface {
+	Parse([]string) error
+}
+
+// Parse will parse the query parameters of the URL of r given the parameter
+// names and parsers defined in schema. Values in the body of r are ignored.
+func Parse(r *http.Request, schema Schema) error {
+	values, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return err
+	}
+	return ParseValues(values, schema)
+}
+
+// ParseValues will parse the query parameters in values given the parameter
+// names and parsers defined in schema.
+func ParseValues(values url.Values, schema Schema) error {
+	for _, name := range slices.Sorted(maps.Keys(schema)) {
+		if err := schema[name].Parse(values[name]); err != nil {
+			return fmt.Errorf("%w: query parameter %q: %w", ErrParseFailure, name, err)
+		}
+	}
+	return nil
+}
+
+// String is used to extract a query parameter into a Go string. If the value
+// is missing then an error is returned during parsing.
+func String(s *string) Parser {
+	return formdata.String(s)
+}
+
+// StringOr is used to extract a query parameter into a Go string. If the value
+// is missing, then the alt value is used instead.
+func StringOr(s *string, alt string) Parser {
+	return formdata.StringOr(s, alt)
+}
+
+// Secret is used to extract a query parameter into a Go conceal.Text. If the
+// value is missing then an error is returned during parsing.
+func Secret(s **conceal.Text) Parser {
+	return formdata.Secret(s)
+}
+
+// Int is used to extract a query parameter into a Go int. If the value is not
+// an int or is missing then an error is returned during parsing.
+func Int(i *int) Parser {
+	return formdata.Int(i)
+}
+
+// IntOr is used to extract a query parameter into a Go int. If the value is
+// missing, then the alt value is used instead.
+func IntOr(i *int, alt int) Parser {
+	return formdata.IntOr(i, alt)
+}
+
+// Float is used to extract a query parameter into a Go float64. If the value
+// is not a float or is missing then an error is returned during parsing.
+func Float(f *float64) Parser {
+	return formdata.Float(f)
+}
+
+// FloatOr is used to extract a query parameter into a Go float64. If the value
+// is missing, then the alt value is used instead.
+func FloatOr(f *float64, alt float64) Parser {
+	return formdata.FloatOr(f, alt)
+}
+
+// Bool is used to extract a query parameter into a Go bool. If the value is
+// not a bool or is missing then an error is returned during parsing.
+func Bool(b *bool) Parser {
+	return formdata.Bool(b)
+}
+
+// BoolOr is used to extract a query parameter into a Go bool. If the value is
+// missing, then the alt value is used instead.
+func BoolOr(b *bool, alt bool) Parser {
+	return formdata.BoolOr(b, alt)
+}
+
+// Duration is used to extract a query parameter into a Go time.Duration. If
+// the value is not a duration or is missing then an error is returned during
+// parsing.
+func Duration(d *time.Duration) Parser {
+	return formdata.Duration(d)
+}
+
+// DurationOr is used to extract a query parameter into a Go time.Duration. If
+// the value is missing, then the alt value is used instead.
+func DurationOr(d *time.Duration, alt time.Duration) Parser {
+	return formdata.DurationOr(d, alt)
+}
+
+// Time is used to extract a query parameter into a Go time.Time, using the
+// given layout as accepted by time.Parse. If layout is empty, then
+// time.RFC3339 is used. If the value is not a time in the layout or is missing
+// then an error is returned during parsing.
+func Time(t *time.Time, layout string) Parser {
+	return formdata.Time(t, layout)
+}
+
+// TimeOr is used to extract a query parameter into a Go time.Time, using the
+// given layout as accepted by time.Parse. If the value is missing, then the
+// alt value is used instead.
+func TimeOr(t *time.Time, layout string, alt time.Time) Parser {
+	return formdata.TimeOr(t, layout, alt)
+}
+
+// Integer is used to extract a query parameter into any Go integer type. If
+// the value is not an integer, does not fit in the type, or is missing then
+// an error is returned during parsing.
+func Integer[T numbers.Integer](i *T) Parser {
+	return formdata.Integer(i)
+}
+
+// IntegerOr is used to extract a query parameter into any Go integer type. If
+// the value is missing, then the alt value is used instead.
+func IntegerOr[T numbers.Integer](i *T, alt T) Parser {
+	return formdata.IntegerOr(i, alt)
+}
+
+// IntegerBetween is used to extract a query parameter into any Go integer
+// type. If the value is not within the inclusive range of lo to hi, then an
+// error wrapping strconv.ErrRange is returned during parsing.
+func IntegerBetween[T numbers.Integer](i *T, lo, hi T) Parser {
+	return formdata.IntegerBetween(i, lo, hi)
+}
+
+// Floating is used to extract a query parameter into any Go floating point
+// type. If the value is not a float, does not fit in the type, or is missing
+// then an error is returned during parsing.
+func Floating[T numbers.Float](f *T) Parser {
+	return formdata.Floating(f)
+}
+
+// FloatingOr is used to extract a query parameter into any Go floating point
+// type. If the value is missing, then the alt value is used instead.
+func FloatingOr[T numbers.Float](f *T, alt T) Parser {
+	return formdata.FloatingOr(f, alt)
+}
+
+// FloatingBetween is used to extract a query parameter into any Go floating
+// point type. If the value is not within the inclusive range of lo to hi, then
+// an error wrapping strconv.ErrRange is returned during parsing.
+func FloatingBetween[T numbers.Float](f *T, lo, hi T) Parser {
+	return formdata.FloatingBetween(f, lo, hi)
+}
+
+// A SliceOption configures the constraints applied by the Strings, Ints,
+// Floats, and Bools parsers to the values of a repeated query parameter.
+type SliceOption = formdata.SliceOption
+
+// MinCount causes an error wrapping ErrTooFewValues if there are fewer than n
+// values.
+func MinCount(n int) SliceOption {
+	return formdata.MinCount(n)
+}
+
+// MaxCount causes an error wrapping ErrTooManyValues if there are more than n
+// values.
+func MaxCount(n int) SliceOption {
+	return formdata.MaxCount(n)
+}
+
+// Unique causes duplicate values to be removed, keeping the first occurrence
+// of each value.
+func Unique() SliceOption {
+	return formdata.Unique()
+}
+
+// Strings is used to extract every value of a repeated query parameter into
+// a Go []string, e.g. ?tag=a&tag=b. If the value is missing then an error is
+// returned during parsing.
+func Strings(s *[]string, opts ...SliceOption) Parser {
+	return formdata.Strings(s, opts...)
+}
+
+// StringsOr is used to extract every value of a repeated query parameter into
+// a Go []string. If the value is missing, then the alt value is used instead.
+func StringsOr(s *[]string, alt []string, opts ...SliceOption) Parser {
+	return formdata.StringsOr(s, alt, opts...)
+}
+
+// Ints is used to extract every value of a repeated query parameter into a Go
+// []int. If any value is not an int or the value is missing then an error is
+// returned during parsing.
+func Ints(i *[]int, opts ...SliceOption) Parser {
+	return formdata.Ints(i, opts...)
+}
+
+// IntsOr is used to extract every value of a repeated query parameter into a
+// Go []int. If the value is missing, then the alt value is used instead.
+func IntsOr(i *[]int, alt []int, opts ...SliceOption) Parser {
+	return formdata.IntsOr(i, alt, opts...)
+}
+
+// Floats is used to extract every value of a repeated query parameter into a
+// Go []float64. If any value is not a float or the value is missing then an
+// error is returned during parsing.
+func Floats(f *[]float64, opts ...SliceOption) Parser {
+	return formdata.Floats(f, opts...)
+}
+
+// FloatsOr is used to extract every value of a repeated query parameter into a
+// Go []float64. If the value is missing, then the alt value is used instead.
+func FloatsOr(f *[]float64, alt []float64, opts ...SliceOption) Parser {
+	return formdata.FloatsOr(f, alt, opts...)
+}
+
+// Bools is used to extract every value of a repeated query parameter into a Go
+// []bool. If any value is not a bool or the value is missing then an error is
+// returned during parsing.
+func Bools(b *[]bool, opts ...SliceOption) Parser {
+	return formdata.Bools(b, opts...)
+}
+
+// BoolsOr is used to extract every value of a repeated query parameter into a
+// Go []bool. If the value is missing, then the alt value is used instead.
+func BoolsOr(b *[]bool, alt []bool, opts ...SliceOption) Parser {
+	return formdata.BoolsOr(b, alt, opts...)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package query
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse(t *testing.T) {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/items?page=2&sort=name&tag=a&tag=b&ttl=1m&token=abc&limit=50", nil)
+	must.NoError(t, err)
+
+	var (
+		page  int
+		sort  string
+		tags  []string
+		ttl   time.Duration
+		token *conceal.Text
+		limit uint16
+		desc  bool
+	)
+
+	err = Parse(request, Schema{
+		"page":  Int(&page),
+		"sort":  String(&sort),
+		"tag":   Strings(&tags),
+		"ttl":   Duration(&ttl),
+		"token": Secret(&token),
+		"limit": IntegerBetween(&limit, 1, 100),
+		"desc":  BoolOr(&desc, true),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 2, page)
+	must.Eq(t, "name", sort)
+	must.Eq(t, []string{"a", "b"}, tags)
+	must.Eq(t, time.Minute, ttl)
+	must.Eq(t, "abc", token.Unveil())
+	must.Eq(t, 50, limit)
+	must.True(t, desc)
+}
+
+func Test_Parse_ignores_body(t *testing.T) {
+	ctx := context.Background()
+	body := strings.NewReader("page=3&sort=date")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/items?page=2", body)
+	must.NoError(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var (
+		page int
+		sort string
+	)
+
+	err = Parse(request, Schema{
+		"page": Int(&page),
+		"sort": StringOr(&sort, "id"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 2, page)
+	must.Eq(t, "id", sort)
+}
+
+func Test_Parse_malformed_query(t *testing.T) {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/items?page=%zz", nil)
+	must.NoError(t, err)
+
+	var page int
+	err = Parse(request, Schema{
+		"page": Int(&page),
+	})
+	must.Error(t, err)
+}
+
+func Test_ParseValues_multi(t *testing.T) {
+	values := url.Values{
+		"id":    []string{"1", "2", "3"},
+		"score": []string{"0.5", "1.5"},
+		"flag":  []string{"true", "false"},
+	}
+
+	var (
+		ids    []int
+		scores []float64
+		flags  []bool
+		names  []string
+	)
+
+	err := ParseValues(values, Schema{
+		"id":    Ints(&ids),
+		"score": Floats(&scores),
+		"flag":  Bools(&flags),
+		"name":  StringsOr(&names, []string{"x"}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []int{1, 2, 3}, ids)
+	must.Eq(t, []float64{0.5, 1.5}, scores)
+	must.Eq(t, []bool{true, false}, flags)
+	must.Eq(t, []string{"x"}, names)
+}
+
+func Test_ParseValues_fail(t *testing.T) {
+	values := url.Values{
+		"id":   []string{"1", "x"},
+		"page": []string{"1", "2"},
+	}
+
+	var ids []int
+	err := ParseValues(values, Schema{
+		"id": Ints(&ids),
+	})
+	must.ErrorIs(t, err, ErrParseFailure)
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.ErrorContains(t, err, `query parameter "id": value 1:`)
+	must.Nil(t, ids)
+
+	var page int
+	err = ParseValues(values, Schema{
+		"page": Int(&page),
+	})
+	must.ErrorIs(t, err, ErrMultipleValues)
+
+	var missing string
+	err = ParseValues(values, Schema{
+		"missing": String(&missing),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_ParseValues_slice_options(t *testing.T) {
+	values := url.Values{
+		"tag": []string{"a", "b", "a"},
+	}
+
+	var tags []string
+	err := ParseValues(values, Schema{
+		"tag": Strings(&tags, Unique(), MinCount(1)),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []string{"a", "b"}, tags)
+
+	err = ParseValues(values, Schema{
+		"tag": Strings(&tags, MaxCount(2)),
+	})
+	must.ErrorIs(t, err, ErrTooManyValues)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package request provides a way to extract values from every part of an
+// *http.Request (path elements, query parameters, form values, headers, and
+// cookies) using a single schema.
+//
+//	var (
+//	    id    int
+//	    page  int
+//	    trace string
+//	)
+//	err := request.Extract(r, request.Schema{
+//	    Path:   urlpath.Schema{"id": urlpath.Int(&id)},
+//	    Query:  query.Schema{"page": query.IntOr(&page, 1)},
+//	    Header: header.Schema{"X-Trace": header.String(&trace)},
+//	})
+package request
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/shoenig/extractors/cookie"
+	"github.com/shoenig/extractors/formdata"
+	"github.com/shoenig/extractors/header"
+	"github.com/shoenig/extractors/query"
+	"github.com/shoenig/extractors/urlpath"
+)
+
+// DefaultMaxMemory is the maximum number of bytes of a multipart form that
+// are stored in memory when extracting Form values, with the remainder stored
+// on disk.
+const DefaultMaxMemory = 32 << 20
+
+// A Schema describes how to extract values from each part of a request. Any
+// part of the Schema may be left empty.
+//
+// Path parameters are looked up using Vars. If Vars is nil, Path is parsed
+// with urlpath.Parse, which requires the request be routed by an
+// http.ServeMux.
+type Schema struct {
+	Vars   urlpath.VarsFunc
+	Path   urlpath.Schema
+	Query  query.Schema
+	Form   formdata.Schema
+	Header header.Schema
+	Cookie cookie.Schema
+}
+
+// A SourceError describes a failure to extract values from one part of a
+// request, where Source is one of "path", "query", "form", "header", or
+// "cookie".
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	return e.Source + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying cause of the failure.
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Extract uses schema to extract values from each part of r. Every part of the
+// schema is extracted even if another part fails, and any failures are
+// returned together as a single error of SourceError values joined with
+// errors.Join.
+//
+// Form values are extracted from only the body of r, unlike formdata.ParseForm
+// which also includes values from the query string. Uploaded files are
+// extracted if r contains a multipart form.
+func Extract(r *http.Request, schema Schema) error {
+	var errs []error
+
+	add := func(source string, err error) {
+		if err != nil {
+			errs = append(errs, &SourceError{Source: source, Err: err})
+		}
+	}
+
+	if len(schema.Path) > 0 {
+		if schema.Vars == nil {
+			add("path", urlpath.Parse(r, schema.Path))
+		} else {
+			add("path", urlpath.ParseWith(r, schema.Vars, schema.Path))
+		}
+	}
+
+	if len(schema.Query) > 0 {
+		add("query", query.Parse(r, schema.Query))
+	}
+
+	if len(schema.Form) > 0 {
+		add("form", parseForm(r, schema.Form))
+	}
+
+	if len(schema.Header) > 0 {
+		add("header", header.ParseRequest(r, schema.Header))
+	}
+
+	if len(schema.Cookie) > 0 {
+		add("cookie", cookie.Parse(r, schema.Cookie))
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseForm parses only the values in the body of r, so that values in the
+// query string are extracted by Query and not by Form.
+func parseForm(r *http.Request, schema formdata.Schema) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(DefaultMaxMemory); err != nil {
+			return err
+		}
+		return formdata.ParseMultipartForm(&multipart.Form{
+			Value: r.PostForm,
+			File:  r.MultipartForm.File,
+		}, schema)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	return formdata.Parse(r.PostForm, schema)
+}
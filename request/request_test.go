@@ -0,0 +1,178 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package request
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/extractors/cookie"
+	"github.com/shoenig/extractors/formdata"
+	"github.com/shoenig/extractors/header"
+	"github.com/shoenig/extractors/query"
+	"github.com/shoenig/extractors/urlpath"
+	"github.com/shoenig/test/must"
+)
+
+func serve(t *testing.T, handler http.HandlerFunc, body string) {
+	router := http.NewServeMux()
+	router.HandleFunc("/v1/items/{id}", handler)
+
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/v1/items/42?page=3", strings.NewReader(body))
+	must.NoError(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("X-Trace", "t-1")
+	request.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+}
+
+func Test_Extract(t *testing.T) {
+	executed := false
+
+	serve(t, func(_ http.ResponseWriter, r *http.Request) {
+		var (
+			id    int
+			page  int
+			name  string
+			trace string
+			theme string
+		)
+
+		err := Extract(r, Schema{
+			Path:   urlpath.Schema{"id": urlpath.Int(&id)},
+			Query:  query.Schema{"page": query.Int(&page)},
+			Form:   formdata.Schema{"name": formdata.String(&name)},
+			Header: header.Schema{"X-Trace": header.String(&trace)},
+			Cookie: cookie.Schema{"theme": cookie.String(&theme)},
+		})
+		must.NoError(t, err)
+		must.Eq(t, 42, id)
+		must.Eq(t, 3, page)
+		must.Eq(t, "bob", name)
+		must.Eq(t, "t-1", trace)
+		must.Eq(t, "dark", theme)
+		executed = true
+	}, "name=bob")
+
+	must.True(t, executed)
+}
+
+func Test_Extract_errors(t *testing.T) {
+	executed := false
+
+	serve(t, func(_ http.ResponseWriter, r *http.Request) {
+		var (
+			id      int
+			page    int
+			age     int
+			tenant  string
+			session string
+		)
+
+		err := Extract(r, Schema{
+			Path:   urlpath.Schema{"id": urlpath.Int(&id)},
+			Query:  query.Schema{"page": query.Int(&page)},
+			Form:   formdata.Schema{"age": formdata.Int(&age)},
+			Header: header.Schema{"X-Tenant": header.String(&tenant)},
+			Cookie: cookie.Schema{"session": cookie.String(&session)},
+		})
+		must.Error(t, err)
+		must.Eq(t, 42, id)
+		must.Eq(t, 3, page)
+
+		must.ErrorIs(t, err, formdata.ErrNoValue)
+		must.ErrorIs(t, err, header.ErrNoValue)
+		must.ErrorIs(t, err, cookie.ErrNoValue)
+
+		var sources []string
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var se *SourceError
+			must.True(t, errors.As(e, &se))
+			sources = append(sources, se.Source)
+		}
+		must.Eq(t, []string{"form", "header", "cookie"}, sources)
+		executed = true
+	}, "")
+
+	must.True(t, executed)
+}
+
+func Test_Extract_Vars(t *testing.T) {
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/items/42", nil)
+	must.NoError(t, err)
+
+	var id int
+	err = Extract(request, Schema{
+		Vars: func(_ *http.Request, name string) (string, bool) {
+			return "7", name == "id"
+		},
+		Path: urlpath.Schema{"id": urlpath.Int(&id)},
+	})
+	must.NoError(t, err)
+	must.Eq(t, 7, id)
+}
+
+func Test_Extract_form_excludes_query(t *testing.T) {
+	executed := false
+
+	serve(t, func(_ http.ResponseWriter, r *http.Request) {
+		var (
+			page  int
+			other int
+		)
+
+		err := Extract(r, Schema{
+			Form: formdata.Schema{
+				"page":  formdata.Int(&page),
+				"other": formdata.Int(&other),
+			},
+		})
+		must.ErrorIs(t, err, formdata.ErrNoValue)
+
+		var errs formdata.Errors
+		must.True(t, errors.As(err, &errs))
+		must.Eq(t, []string{"page"}, errs.Fields())
+		must.Eq(t, 0, page)
+		must.Eq(t, 1, other)
+		executed = true
+	}, "other=1")
+
+	must.True(t, executed)
+}
+
+func Test_Extract_multipart_excludes_query(t *testing.T) {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	must.NoError(t, w.WriteField("other", "1"))
+	must.NoError(t, w.Close())
+
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/upload?page=3", body)
+	must.NoError(t, err)
+	request.Header.Set("Content-Type", w.FormDataContentType())
+
+	var (
+		page  int
+		other int
+	)
+
+	err = Extract(request, Schema{
+		Form: formdata.Schema{
+			"page":  formdata.IntOr(&page, 1),
+			"other": formdata.Int(&other),
+		},
+	})
+	must.NoError(t, err)
+	must.Eq(t, 1, page)
+	must.Eq(t, 1, other)
+}
@@ -0,0 +1,13 @@
+module github.com/shoenig/extractors/urlpath/gorilla
+
+go 1.23
+
+require (
+	github.com/gorilla/mux v1.8.1
+	github.com/shoenig/extractors v0.0.0
+	github.com/shoenig/test v1.8.2
+)
+
+require github.com/google/go-cmp v0.6.0 // indirect
+
+replace github.com/shoenig/extractors => ../..
@@ -0,0 +1,40 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package gorilla provides support for extracting URL path parameters from
+// requests being processed by handlers of a gorilla/mux router.
+//
+//	// in the router definition, e.g.
+//	router.Handle("/v1/{foo}/{bar}", newHandler())
+//
+//	// in the handler implementation, e.g.
+//	var foo string
+//	var bar int
+//	err := gorilla.Parse(request, urlpath.Schema{
+//	    "foo": urlpath.String(&foo),
+//	    "bar": urlpath.Int(&bar),
+//	})
+package gorilla
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shoenig/extractors/urlpath"
+)
+
+// Vars is a urlpath.VarsFunc for requests being processed by handlers of a
+// gorilla/mux router, using mux.Vars.
+func Vars(r *http.Request, name string) (string, bool) {
+	value, exists := mux.Vars(r)[name]
+	return value, exists
+}
+
+// Parse will parse the URL path vars from r given the element names and
+// parsers defined in schema.
+//
+// This method only works with requests being processed by handlers of a
+// gorilla/mux.
+func Parse(r *http.Request, schema urlpath.Schema) error {
+	return urlpath.ParseWith(r, Vars, schema)
+}
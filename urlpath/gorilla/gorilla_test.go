@@ -0,0 +1,69 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gorilla
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/shoenig/extractors/urlpath"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse(t *testing.T) {
+	router := mux.NewRouter()
+	executed := false
+
+	router.HandleFunc("/v1/{foo}/{bar}", func(_ http.ResponseWriter, r *http.Request) {
+		var foo string
+		var bar int
+
+		err := Parse(r, urlpath.Schema{
+			"foo": urlpath.String(&foo),
+			"bar": urlpath.Int(&bar),
+		})
+
+		must.NoError(t, err)
+		must.EqOp(t, "blah", foo)
+		must.EqOp(t, 31, bar)
+
+		var baz string
+		err = Parse(r, urlpath.Schema{
+			"baz": urlpath.String(&baz),
+		})
+		must.Error(t, err)
+		executed = true
+	})
+
+	w := httptest.NewRecorder()
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/blah/31", nil)
+	must.NoError(t, err)
+
+	router.ServeHTTP(w, request)
+	must.True(t, executed)
+}
+
+func Test_Parse_urlpath(t *testing.T) {
+	router := mux.NewRouter()
+	executed := false
+
+	router.HandleFunc("/v1/{foo}", func(_ http.ResponseWriter, r *http.Request) {
+		var foo string
+		err := urlpath.Parse(r, urlpath.Schema{
+			"foo": urlpath.String(&foo),
+		})
+		must.ErrorIs(t, err, urlpath.ErrNotServeMux)
+		executed = true
+	})
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/blah", nil)
+	must.NoError(t, err)
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+	must.True(t, executed)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"github.com/shoenig/extractors/internal/ids"
+)
+
+type idParser[T any] struct {
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (p *idParser[T]) Parse(s string) error {
+	id, err := p.convert(s)
+	if err != nil {
+		return err
+	}
+	*p.destination = id
+	return nil
+}
+
+// UUID creates a Parser that will parse a path element in the canonical
+// RFC 9562 form into u, which may be any type of [16]byte such as uuid.UUID.
+// If versions are given, then the UUID must be one of those versions.
+func UUID[T ~[16]byte](u *T, versions ...int) Parser {
+	return &idParser[T]{
+		convert:     ids.UUIDFunc[T](versions...),
+		destination: u,
+	}
+}
+
+// ULID creates a Parser that will parse a path element into u, which may be
+// any type of [16]byte such as ulid.ULID.
+func ULID[T ~[16]byte](u *T) Parser {
+	return &idParser[T]{
+		convert:     ids.ParseULID[T],
+		destination: u,
+	}
+}
+
+// KSUID creates a Parser that will parse a path element into k, which may be
+// any type of [20]byte such as ksuid.KSUID.
+func KSUID[T ~[20]byte](k *T) Parser {
+	return &idParser[T]{
+		convert:     ids.ParseKSUID[T],
+		destination: k,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type userID [16]byte
+
+func Test_ParseValues_ids(t *testing.T) {
+	var (
+		user  userID
+		order [16]byte
+		event [20]byte
+	)
+
+	err := ParseValues(map[string]string{
+		"user":  "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"order": "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"event": "0ujtsYcgvSTl8PAuAdqWYSMnLOv",
+	}, Schema{
+		"user":  UUID(&user, 4),
+		"order": ULID(&order),
+		"event": KSUID(&event),
+	})
+	must.NoError(t, err)
+	must.Eq(t, userID{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}, user)
+	must.EqOp(t, 0x01, order[0])
+	must.EqOp(t, 0x5b, order[15])
+	must.EqOp(t, 0x06, event[0])
+	must.EqOp(t, 0x35, event[19])
+}
+
+func Test_ParseValues_ids_fail(t *testing.T) {
+	var user userID
+
+	err := ParseValues(map[string]string{"user": "42"}, Schema{
+		"user": UUID(&user),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.EqError(t, err, `could not parse url path variable "{user}": unable to parse "42" as UUID: invalid syntax`)
+
+	err = ParseValues(map[string]string{"user": "f47ac10b-58cc-4372-a567-0e02b2c3d479"}, Schema{
+		"user": UUID(&user, 7),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.ErrorContains(t, err, "version 4 is not one of versions [7]")
+	must.Eq(t, userID{}, user)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"github.com/shoenig/extractors/internal/numbers"
+)
+
+type numberParser[T numbers.Number] struct {
+	bounded     bool
+	lo, hi      T
+	convert     func(string) (T, error)
+	destination *T
+}
+
+func (p *numberParser[T]) Parse(s string) error {
+	n, err := p.convert(s)
+	if err != nil {
+		return err
+	}
+
+	if p.bounded {
+		if err = numbers.CheckRange(n, p.lo, p.hi); err != nil {
+			return err
+		}
+	}
+
+	*p.destination = n
+	return nil
+}
+
+// Integer creates a Parser that will parse a path element into i, which may
+// be any Go integer type.
+func Integer[T numbers.Integer](i *T) Parser {
+	return &numberParser[T]{
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// IntegerOr creates an Optional parser that will parse a path element into i,
+// which may be any Go integer type. If the path element is not present, then
+// the alt value is used instead.
+func IntegerOr[T numbers.Integer](i *T, alt T) Parser {
+	*i = alt
+	return Optional(Integer(i))
+}
+
+// IntegerBetween creates a Parser that will parse a path element into i, which
+// may be any Go integer type. If the value is not within the inclusive range
+// of lo to hi, an error wrapping strconv.ErrRange is returned.
+func IntegerBetween[T numbers.Integer](i *T, lo, hi T) Parser {
+	return &numberParser[T]{
+		bounded:     true,
+		lo:          lo,
+		hi:          hi,
+		convert:     numbers.ParseInteger[T],
+		destination: i,
+	}
+}
+
+// Floating creates a Parser that will parse a path element into f, which may
+// be any Go floating point type.
+func Floating[T numbers.Float](f *T) Parser {
+	return &numberParser[T]{
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
+
+// FloatingOr creates an Optional parser that will parse a path element into f,
+// which may be any Go floating point type. If the path element is not present,
+// then the alt value is used instead.
+func FloatingOr[T numbers.Float](f *T, alt T) Parser {
+	*f = alt
+	return Optional(Floating(f))
+}
+
+// FloatingBetween creates a Parser that will parse a path element into f,
+// which may be any Go floating point type. If the value is not within the
+// inclusive range of lo to hi, an error wrapping strconv.ErrRange is returned.
+func FloatingBetween[T numbers.Float](f *T, lo, hi T) Parser {
+	return &numberParser[T]{
+		bounded:     true,
+		lo:          lo,
+		hi:          hi,
+		convert:     numbers.ParseFloat[T],
+		destination: f,
+	}
+}
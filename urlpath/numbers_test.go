@@ -0,0 +1,79 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_ParseValues_numbers(t *testing.T) {
+	var (
+		id    uint32
+		page  int8
+		lat   float32
+		ratio float64
+	)
+
+	values := map[string]string{
+		"id":    "4000000000",
+		"page":  "7",
+		"lat":   "45.5",
+		"ratio": "0.5",
+	}
+
+	err := ParseValues(values, Schema{
+		"id":    Integer(&id),
+		"page":  IntegerBetween(&page, 1, 10),
+		"lat":   Floating(&lat),
+		"ratio": FloatingBetween(&ratio, 0, 1),
+	})
+
+	must.NoError(t, err)
+	must.EqOp(t, 4000000000, id)
+	must.EqOp(t, 7, page)
+	must.EqOp(t, 45.5, lat)
+	must.EqOp(t, 0.5, ratio)
+}
+
+func Test_ParseValues_numbers_fail(t *testing.T) {
+	var id uint32
+	err := ParseValues(map[string]string{"id": "5000000000"}, Schema{
+		"id": Integer(&id),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	var page int8
+	err = ParseValues(map[string]string{"page": "11"}, Schema{
+		"page": IntegerBetween(&page, 1, 10),
+	})
+	must.ErrorIs(t, err, strconv.ErrRange)
+
+	var lat float32
+	err = ParseValues(map[string]string{"lat": "north"}, Schema{
+		"lat": Floating(&lat),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func Test_ParseValues_numbers_Or(t *testing.T) {
+	var (
+		id    uint32
+		ratio float32
+		page  int16
+	)
+
+	err := ParseValues(map[string]string{"page": "3"}, Schema{
+		"id":    IntegerOr(&id, 1),
+		"ratio": FloatingOr(&ratio, 0.5),
+		"page":  IntegerOr(&page, 1),
+	})
+
+	must.NoError(t, err)
+	must.EqOp(t, 1, id)
+	must.EqOp(t, 0.5, ratio)
+	must.EqOp(t, 3, page)
+}
@@ -4,17 +4,28 @@
 package urlpath
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+)
 
-	"github.com/gorilla/mux"
+var (
+	ErrNotPresent    = errors.New("url path element not present")
+	ErrNotAllowed    = errors.New("value is not allowed")
+	ErrNoMatch       = errors.New("value does not match pattern")
+	ErrInvalidLength = errors.New("value has invalid length")
+	ErrNotServeMux   = errors.New("request was not routed by an http.ServeMux")
 )
 
 // Typical usage:
 //
 //    // in the mux definition, e.g.
-//    router.Handle("/v1/{foo}/{bar}, newHandler())
+//    mux.Handle("/v1/{foo}/{bar}", newHandler())
 //
 //    // in the handler implementation, e.g.
 //    var foo string
@@ -25,8 +36,8 @@ import (
 //    })
 
 // A Parameter is a named element of a URL route,
-// encoded such that a gorilla router interprets it
-// as a path parameter.
+// encoded such that an http.ServeMux (or a gorilla
+// router) interprets it as a path parameter.
 type Parameter string
 
 func (p Parameter) String() string {
@@ -39,16 +50,81 @@ func (p Parameter) Name() string {
 }
 
 // A Schema describes how path variables should be parsed.
-// Currently only int and string types are supported.
 type Schema map[Parameter]Parser
 
+// A VarsFunc returns the value of the path parameter of r with the given
+// name, and whether the parameter exists. Implementing a VarsFunc enables
+// extracting path parameters from requests handled by any router.
+//
+// For example, using chi:
+//
+//	func(r *http.Request, name string) (string, bool) {
+//	    value := chi.URLParam(r, name)
+//	    return value, value != ""
+//	}
+type VarsFunc func(r *http.Request, name string) (string, bool)
+
+// PathValue is a VarsFunc for requests being processed by handlers of an
+// http.ServeMux, using http.Request.PathValue. A parameter exists if it is a
+// wildcard of the pattern that matched r, in which case its value may be
+// empty, e.g. {path...} for a request of /files/. If r has no pattern, such
+// as when using http.Request.SetPathValue in a test, a parameter with an empty
+// value is treated as not existing.
+func PathValue(r *http.Request, name string) (string, bool) {
+	value := r.PathValue(name)
+	if r.Pattern == "" {
+		return value, value != ""
+	}
+	return value, hasWildcard(r.Pattern, name)
+}
+
+// hasWildcard returns whether the http.ServeMux pattern contains a wildcard
+// with the given name, i.e. {name} or {name...}.
+func hasWildcard(pattern, name string) bool {
+	return strings.Contains(pattern, "{"+name+"}") ||
+		strings.Contains(pattern, "{"+name+"...}")
+}
+
 // Parse will parse the URL path vars from r given the
 // element names and parsers defined in schema.
 //
-// This method only works with requests being processed by
-// handlers of a gorilla/mux.
+// This method works with requests being processed by
+// handlers of an http.ServeMux. For other routers use
+// ParseWith, or the gorilla sub-package for a gorilla/mux.
+//
+// If r was not routed by an http.ServeMux and none of the
+// elements of schema have a value set by
+// http.Request.SetPathValue, an error wrapping ErrNotServeMux
+// is returned rather than reporting every element as not
+// present.
 func Parse(r *http.Request, schema Schema) error {
-	return ParseValues(mux.Vars(r), schema)
+	if r.Pattern == "" && len(schema) > 0 && !hasPathValues(r, schema) {
+		return fmt.Errorf("%w: use ParseWith, or gorilla.Parse for a gorilla/mux router", ErrNotServeMux)
+	}
+	return ParseWith(r, PathValue, schema)
+}
+
+// hasPathValues returns whether any element of schema has a value in r.
+func hasPathValues(r *http.Request, schema Schema) bool {
+	for name := range schema {
+		if r.PathValue(name.Name()) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWith will parse the URL path vars from r given the
+// element names and parsers defined in schema, looking up
+// the value of each element using vars.
+func ParseWith(r *http.Request, vars VarsFunc, schema Schema) error {
+	values := make(map[string]string, len(schema))
+	for name := range schema {
+		if value, exists := vars(r, name.Name()); exists {
+			values[name.Name()] = value
+		}
+	}
+	return ParseValues(values, schema)
 }
 
 // ParseValues will parse the parameters in vars given the
@@ -56,25 +132,73 @@ func Parse(r *http.Request, schema Schema) error {
 //
 // Most use cases will be parsing values coming from an *http.Request,
 // which can be done conveniently with Parse.
+//
+// An error is returned if an element is not present in values, unless
+// its parser is Optional.
+//
+// Any error is a *ParameterError naming the first element (in sorted
+// order) that failed to parse.
 func ParseValues(values map[string]string, schema Schema) error {
-	for name, parser := range schema {
+	for _, name := range slices.Sorted(maps.Keys(schema)) {
+		parser := schema[name]
 		value, exists := values[name.Name()]
 		if !exists {
-			return fmt.Errorf("url path element not present: %q", name)
+			if _, ok := parser.(optional); ok {
+				continue
+			}
+			return &ParameterError{Parameter: name, Err: ErrNotPresent}
 		}
 
 		if err := parser.Parse(value); err != nil {
-			return fmt.Errorf("could not parse url path variable: %w", err)
+			return &ParameterError{Parameter: name, Err: err}
 		}
 	}
 	return nil
 }
 
+// A ParameterError describes the failure to parse a single Parameter.
+//
+// A failure wrapping ErrNotPresent or ErrNotAllowed typically indicates the
+// request does not refer to an existing resource (i.e. 404 Not Found), where
+// other failures typically indicate a malformed request (i.e. 400 Bad Request).
+type ParameterError struct {
+	Parameter Parameter
+	Err       error
+}
+
+func (e *ParameterError) Error() string {
+	return fmt.Sprintf("could not parse url path variable %q: %v", e.Parameter, e.Err)
+}
+
+// Unwrap returns the underlying cause of the failure.
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
+
 // A Parser parses raw input into a destination variable.
 type Parser interface {
 	Parse(string) error
 }
 
+// optional is implemented by parsers of path elements that need not be
+// present.
+type optional interface {
+	optional()
+}
+
+type optionalParser struct {
+	Parser
+}
+
+func (optionalParser) optional() {}
+
+// Optional wraps p such that the path element need not be present, in which
+// case the destination of p is left unchanged. This enables sharing one Schema
+// across routes such as /v1/items and /v1/items/{id}.
+func Optional(p Parser) Parser {
+	return optionalParser{Parser: p}
+}
+
 type stringParser struct {
 	destination *string
 }
@@ -84,6 +208,13 @@ func String(s *string) Parser {
 	return &stringParser{destination: s}
 }
 
+// StringOr creates an Optional parser that will parse a path element into s.
+// If the path element is not present, then the alt value is used instead.
+func StringOr(s *string, alt string) Parser {
+	*s = alt
+	return Optional(String(s))
+}
+
 func (p *stringParser) Parse(s string) error {
 	*p.destination = s
 	return nil
@@ -98,6 +229,13 @@ func Int(i *int) Parser {
 	return &intParser{destination: i}
 }
 
+// IntOr creates an Optional parser that will parse a path element into i. If
+// the path element is not present, then the alt value is used instead.
+func IntOr(i *int, alt int) Parser {
+	*i = alt
+	return Optional(Int(i))
+}
+
 func (p *intParser) Parse(s string) error {
 	i, err := strconv.Atoi(s)
 	if err != nil {
@@ -107,19 +245,67 @@ func (p *intParser) Parse(s string) error {
 	return nil
 }
 
-type uint64Parser struct {
-	destination *uint64
+// UInt64 creates a Parser that will parse a path element into i.
+//
+// Equivalent to Integer(i).
+func UInt64(i *uint64) Parser {
+	return Integer(i)
 }
 
-func UInt64(i *uint64) Parser {
-	return &uint64Parser{destination: i}
+type durationParser struct {
+	destination *time.Duration
+}
+
+// Duration creates a Parser that will parse a path element into d, using the
+// format accepted by time.ParseDuration.
+func Duration(d *time.Duration) Parser {
+	return &durationParser{destination: d}
+}
+
+// DurationOr creates an Optional parser that will parse a path element into d.
+// If the path element is not present, then the alt value is used instead.
+func DurationOr(d *time.Duration, alt time.Duration) Parser {
+	*d = alt
+	return Optional(Duration(d))
+}
+
+func (p *durationParser) Parse(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*p.destination = d
+	return nil
+}
+
+type timeParser struct {
+	layout      string
+	destination *time.Time
+}
+
+// Time creates a Parser that will parse a path element into t, using the
+// given layout as accepted by time.Parse. If layout is empty, then
+// time.RFC3339 is used.
+func Time(t *time.Time, layout string) Parser {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return &timeParser{layout: layout, destination: t}
+}
+
+// TimeOr creates an Optional parser that will parse a path element into t,
+// using the given layout as accepted by time.Parse. If the path element is not
+// present, then the alt value is used instead.
+func TimeOr(t *time.Time, layout string, alt time.Time) Parser {
+	*t = alt
+	return Optional(Time(t, layout))
 }
 
-func (u uint64Parser) Parse(s string) error {
-	i, err := strconv.ParseUint(s, 10, 64)
+func (p *timeParser) Parse(s string) error {
+	t, err := time.Parse(p.layout, s)
 	if err != nil {
 		return err
 	}
-	*u.destination = i
+	*p.destination = t
 	return nil
 }
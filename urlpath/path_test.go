@@ -8,13 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	"github.com/gorilla/mux"
 	"github.com/shoenig/test/must"
 )
 
 func Test_Parse(t *testing.T) {
-	router := mux.NewRouter()
+	router := http.NewServeMux()
 	executed := false
 
 	router.HandleFunc("/v1/{foo}/{bar}", func(_ http.ResponseWriter, r *http.Request) {
@@ -41,6 +41,33 @@ func Test_Parse(t *testing.T) {
 	must.True(t, executed)
 }
 
+func Test_ParseWith(t *testing.T) {
+	vars := func(_ *http.Request, name string) (string, bool) {
+		value, exists := map[string]string{"foo": "blah", "bar": "31"}[name]
+		return value, exists
+	}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+	must.NoError(t, err)
+
+	var foo string
+	var bar int
+	err = ParseWith(request, vars, Schema{
+		"foo": String(&foo),
+		"bar": Int(&bar),
+	})
+	must.NoError(t, err)
+	must.EqOp(t, "blah", foo)
+	must.EqOp(t, 31, bar)
+
+	var baz string
+	err = ParseWith(request, vars, Schema{
+		"baz": String(&baz),
+	})
+	must.ErrorIs(t, err, ErrNotPresent)
+	must.EqError(t, err, `could not parse url path variable "{baz}": url path element not present`)
+}
+
 func Test_ParseValues(t *testing.T) {
 	var foo string
 	var bar int
@@ -64,6 +91,34 @@ func Test_ParseValues(t *testing.T) {
 	must.EqOp(t, 42, id)
 }
 
+func Test_ParseValues_time(t *testing.T) {
+	var ttl time.Duration
+	var day time.Time
+	var at time.Time
+
+	values := map[string]string{
+		"ttl": "10m",
+		"day": "2024-05-06",
+		"at":  "2024-05-06T07:08:09Z",
+	}
+
+	err := ParseValues(values, Schema{
+		"ttl": Duration(&ttl),
+		"day": Time(&day, time.DateOnly),
+		"at":  Time(&at, ""),
+	})
+
+	must.NoError(t, err)
+	must.EqOp(t, 10*time.Minute, ttl)
+	must.Eq(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), day)
+	must.Eq(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), at)
+
+	err = ParseValues(map[string]string{"ttl": "10"}, Schema{
+		"ttl": Duration(&ttl),
+	})
+	must.Error(t, err)
+}
+
 func Test_ParseValues_incompatible(t *testing.T) {
 	var foo string
 	var bar int
@@ -97,6 +152,125 @@ func Test_ParseValues_missing(t *testing.T) {
 	must.Error(t, err)
 }
 
+func Test_ParseValues_Or(t *testing.T) {
+	var (
+		kind string
+		id   int
+		ttl  time.Duration
+		day  time.Time
+	)
+
+	fallback := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	schema := Schema{
+		"kind": String(&kind),
+		"id":   IntOr(&id, -1),
+		"ttl":  DurationOr(&ttl, time.Minute),
+		"day":  TimeOr(&day, time.DateOnly, fallback),
+	}
+
+	err := ParseValues(map[string]string{"kind": "items"}, schema)
+	must.NoError(t, err)
+	must.EqOp(t, "items", kind)
+	must.EqOp(t, -1, id)
+	must.EqOp(t, time.Minute, ttl)
+	must.Eq(t, fallback, day)
+
+	err = ParseValues(map[string]string{"kind": "items", "id": "7", "ttl": "1h", "day": "2025-01-02"}, schema)
+	must.NoError(t, err)
+	must.EqOp(t, 7, id)
+	must.EqOp(t, time.Hour, ttl)
+	must.Eq(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), day)
+
+	err = ParseValues(map[string]string{"kind": "items", "id": "x"}, schema)
+	must.Error(t, err)
+}
+
+func Test_Parse_Optional(t *testing.T) {
+	router := http.NewServeMux()
+	var results []string
+
+	handler := func(_ http.ResponseWriter, r *http.Request) {
+		var id string
+		name := "none"
+		err := Parse(r, Schema{
+			"id":   Optional(String(&id)),
+			"name": StringOr(&name, "none"),
+		})
+		must.NoError(t, err)
+		results = append(results, id+"/"+name)
+	}
+	router.HandleFunc("/v1/items", handler)
+	router.HandleFunc("/v1/items/{id}", handler)
+
+	for _, path := range []string{"/v1/items", "/v1/items/42"} {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+		must.NoError(t, err)
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	must.Eq(t, []string{"/none", "42/none"}, results)
+}
+
+func Test_Parse_remainder_empty(t *testing.T) {
+	router := http.NewServeMux()
+	var results []string
+
+	router.HandleFunc("/files/{path...}", func(_ http.ResponseWriter, r *http.Request) {
+		path := "unset"
+		err := Parse(r, Schema{
+			"path": String(&path),
+		})
+		must.NoError(t, err)
+		results = append(results, path)
+	})
+
+	for _, path := range []string{"/files/", "/files/a/b"} {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+		must.NoError(t, err)
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	must.Eq(t, []string{"", "a/b"}, results)
+}
+
+func Test_Parse_SetPathValue(t *testing.T) {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/blah", nil)
+	must.NoError(t, err)
+	request.SetPathValue("foo", "blah")
+
+	var foo string
+	err = Parse(request, Schema{
+		"foo": String(&foo),
+	})
+	must.NoError(t, err)
+	must.EqOp(t, "blah", foo)
+
+	var bar string
+	err = Parse(request, Schema{
+		"foo": String(&foo),
+		"bar": String(&bar),
+	})
+	must.ErrorIs(t, err, ErrNotPresent)
+
+	err = Parse(request, Schema{
+		"bar": String(&bar),
+	})
+	must.ErrorIs(t, err, ErrNotServeMux)
+}
+
+func Test_PathValue(t *testing.T) {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/files/", nil)
+	must.NoError(t, err)
+	request.Pattern = "GET /files/{path...}"
+
+	value, exists := PathValue(request, "path")
+	must.True(t, exists)
+	must.EqOp(t, "", value)
+
+	_, exists = PathValue(request, "other")
+	must.False(t, exists)
+}
+
 func Test_Parameter_String(t *testing.T) {
 	p := Parameter("foo")
 	s := p.String()
@@ -0,0 +1,325 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// A Route is a template of a URL path containing Parameters, which can be
+// used both to register a handler with a router, and to build concrete URLs
+// from parameter values.
+//
+// A Parameter in the template may be constrained by a regular expression
+// as understood by a gorilla/mux router, e.g. {id:[0-9]+}, or match the
+// remainder of the path as understood by an http.ServeMux, e.g. {path...}.
+//
+//	var itemRoute = urlpath.MustRoute("/v1/{kind}/{id:[0-9]+}", urlpath.Schema{
+//	    "kind": urlpath.String(&kind),
+//	    "id":   urlpath.Int(&id),
+//	})
+//
+//	mux.Handle(itemRoute.Pattern(), handler)
+//	link, err := itemRoute.Build(map[string]string{"kind": "book", "id": "42"})
+//
+// As with an http.ServeMux pattern, the template may begin with a method
+// and/or a host, e.g. "GET example.com/v1/{id}", which are kept by Pattern
+// but are not part of the URL path returned by Build.
+type Route struct {
+	template string
+	prefix   string // the method and host, if any
+	segments []segment
+}
+
+// A segment is either literal text of a Route template, or a Parameter.
+type segment struct {
+	literal   string
+	parameter Parameter
+	raw       string // the original text of the parameter, including braces
+	pattern   *regexp.Regexp
+	remainder bool
+}
+
+func (s segment) isParameter() bool {
+	return s.parameter != ""
+}
+
+var parameterName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewRoute parses template into a Route, and verifies that schema covers
+// exactly the Parameters of the template. Parameters of schema that are
+// not in the template are allowed only if their parser is Optional, so that
+// one Schema can be shared across related routes. If schema is nil, then
+// no verification is done.
+func NewRoute(template string, schema Schema) (*Route, error) {
+	start, err := pathStart(template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid route %q: %w", template, err)
+	}
+
+	segments, err := parseTemplate(template, start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid route %q: %w", template, err)
+	}
+
+	r := &Route{
+		template: template,
+		prefix:   template[:start],
+		segments: segments,
+	}
+
+	if schema != nil {
+		if err = r.check(schema); err != nil {
+			return nil, fmt.Errorf("invalid route %q: %w", template, err)
+		}
+	}
+
+	return r, nil
+}
+
+// MustRoute is like NewRoute, but panics if template cannot be parsed or is
+// not covered by schema. It simplifies the initialization of global variables
+// holding a Route.
+func MustRoute(template string, schema Schema) *Route {
+	r, err := NewRoute(template, schema)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// pathStart returns the offset of the path in template, after any method and
+// host, e.g. 4 for "GET /v1/{id}".
+func pathStart(template string) (int, error) {
+	start := 0
+	if method, _, found := strings.Cut(template, " "); found && !strings.Contains(method, "/") {
+		start = len(method)
+		for start < len(template) && (template[start] == ' ' || template[start] == '\t') {
+			start++
+		}
+	}
+
+	slash := strings.IndexByte(template[start:], '/')
+	switch {
+	case slash < 0 && start > 0:
+		return 0, fmt.Errorf("missing path after method")
+	case slash < 0:
+		return 0, nil
+	}
+
+	if strings.ContainsAny(template[:start+slash], "{}") {
+		return 0, fmt.Errorf("parameters are not allowed in the method or host")
+	}
+	return start + slash, nil
+}
+
+func parseTemplate(template string, start int) ([]segment, error) {
+	var segments []segment
+	seen := make(map[Parameter]bool)
+
+	for i := start; i < len(template); {
+		switch template[i] {
+		case '}':
+			return nil, fmt.Errorf("unexpected '}' at offset %d", i)
+		case '{':
+			end, err := closingBrace(template, i)
+			if err != nil {
+				return nil, err
+			}
+
+			s, err := parseParameter(template[i : end+1])
+			if err != nil {
+				return nil, err
+			}
+
+			if s.isParameter() {
+				if seen[s.parameter] {
+					return nil, fmt.Errorf("parameter %q is declared more than once", s.parameter.Name())
+				}
+				seen[s.parameter] = true
+			}
+
+			segments = append(segments, s)
+			i = end + 1
+		default:
+			end := strings.IndexAny(template[i:], "{}")
+			if end < 0 {
+				end = len(template) - i
+			}
+			segments = append(segments, segment{literal: template[i : i+end]})
+			i += end
+		}
+	}
+
+	return segments, nil
+}
+
+// closingBrace returns the offset of the brace that closes the brace at
+// offset start, accounting for braces nested within a regular expression.
+func closingBrace(template string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(template); i++ {
+		switch template[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unclosed '{' at offset %d", start)
+}
+
+func parseParameter(raw string) (segment, error) {
+	inner := raw[1 : len(raw)-1]
+
+	// the http.ServeMux end of path anchor, which is not a parameter
+	if inner == "$" {
+		return segment{literal: "", raw: raw}, nil
+	}
+
+	name, expr, constrained := strings.Cut(inner, ":")
+	s := segment{raw: raw}
+
+	if strings.HasSuffix(name, "...") {
+		name = strings.TrimSuffix(name, "...")
+		s.remainder = true
+	}
+
+	if !parameterName.MatchString(name) {
+		return segment{}, fmt.Errorf("invalid parameter name %q", name)
+	}
+	s.parameter = Parameter(name)
+
+	if constrained {
+		if s.remainder {
+			return segment{}, fmt.Errorf("parameter %q cannot have both a pattern and match the remainder", name)
+		}
+		if _, err := regexp.Compile(expr); err != nil {
+			return segment{}, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		s.pattern = regexp.MustCompile("^(?:" + expr + ")$")
+	}
+
+	return s, nil
+}
+
+func (r *Route) check(schema Schema) error {
+	parameters := r.Parameters()
+
+	for _, p := range parameters {
+		if _, exists := schema[p]; !exists {
+			return fmt.Errorf("parameter %q is not in schema", p.Name())
+		}
+	}
+
+	for _, p := range slices.Sorted(maps.Keys(schema)) {
+		if slices.Contains(parameters, p) {
+			continue
+		}
+		if _, ok := schema[p].(optional); !ok {
+			return fmt.Errorf("schema parameter %q is not in route and is not optional", p.Name())
+		}
+	}
+
+	return nil
+}
+
+// String returns the template of the Route, as given to NewRoute. The
+// template is suitable for registering with a gorilla/mux router.
+func (r *Route) String() string {
+	return r.template
+}
+
+// Pattern returns the template of the Route with any regular expressions
+// removed from its Parameters, e.g. /v1/{kind}/{id}. The pattern is suitable
+// for registering with an http.ServeMux.
+func (r *Route) Pattern() string {
+	var sb strings.Builder
+	sb.WriteString(r.prefix)
+	for _, s := range r.segments {
+		switch {
+		case s.remainder:
+			sb.WriteString("{" + s.parameter.Name() + "...}")
+		case s.isParameter():
+			sb.WriteString(s.parameter.String())
+		case s.raw != "":
+			sb.WriteString(s.raw)
+		default:
+			sb.WriteString(s.literal)
+		}
+	}
+	return sb.String()
+}
+
+// Parameters returns the Parameters of the Route, in the order they appear
+// in the template.
+func (r *Route) Parameters() []Parameter {
+	var parameters []Parameter
+	for _, s := range r.segments {
+		if s.isParameter() {
+			parameters = append(parameters, s.parameter)
+		}
+	}
+	return parameters
+}
+
+// Build returns the URL path of the Route with each Parameter replaced by its
+// value in values, escaped with url.PathEscape. Any method or host of the
+// template is not included. Slashes in the value of a
+// Parameter matching the remainder of the path are preserved.
+//
+// An error is returned if a Parameter has no value or a value that does not
+// match its regular expression, or if values contains a name that is not a
+// Parameter of the Route.
+func (r *Route) Build(values map[string]string) (string, error) {
+	var sb strings.Builder
+	used := 0
+
+	for _, s := range r.segments {
+		if !s.isParameter() {
+			sb.WriteString(s.literal)
+			continue
+		}
+
+		value, exists := values[s.parameter.Name()]
+		switch {
+		case !exists:
+			return "", fmt.Errorf("%w: %q", ErrNotPresent, s.parameter)
+		case value == "" && !s.remainder:
+			return "", fmt.Errorf("url path element is empty: %q", s.parameter)
+		case s.pattern != nil && !s.pattern.MatchString(value):
+			return "", fmt.Errorf("url path element %q does not match %s: %q", s.parameter, s.raw, value)
+		}
+		used++
+
+		if s.remainder {
+			parts := strings.Split(value, "/")
+			for i, part := range parts {
+				parts[i] = url.PathEscape(part)
+			}
+			sb.WriteString(strings.Join(parts, "/"))
+		} else {
+			sb.WriteString(url.PathEscape(value))
+		}
+	}
+
+	if used < len(values) {
+		parameters := r.Parameters()
+		for _, name := range slices.Sorted(maps.Keys(values)) {
+			if !slices.Contains(parameters, Parameter(name)) {
+				return "", fmt.Errorf("url path element is not in route: %q", name)
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,252 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_NewRoute(t *testing.T) {
+	var (
+		kind string
+		id   int
+	)
+
+	r, err := NewRoute("/v1/{kind}/{id:[0-9]{1,4}}", Schema{
+		"kind": String(&kind),
+		"id":   Int(&id),
+	})
+	must.NoError(t, err)
+	must.EqOp(t, "/v1/{kind}/{id:[0-9]{1,4}}", r.String())
+	must.EqOp(t, "/v1/{kind}/{id}", r.Pattern())
+	must.Eq(t, []Parameter{"kind", "id"}, r.Parameters())
+}
+
+func Test_NewRoute_ServeMux(t *testing.T) {
+	r := MustRoute("/files/{bucket}/{path...}", nil)
+	must.EqOp(t, "/files/{bucket}/{path...}", r.Pattern())
+	must.Eq(t, []Parameter{"bucket", "path"}, r.Parameters())
+
+	r = MustRoute("/v1/items/{$}", nil)
+	must.EqOp(t, "/v1/items/{$}", r.Pattern())
+	must.SliceEmpty(t, r.Parameters())
+
+	link, err := r.Build(nil)
+	must.NoError(t, err)
+	must.EqOp(t, "/v1/items/", link)
+}
+
+func Test_NewRoute_method_host(t *testing.T) {
+	cases := []struct {
+		template string
+		pattern  string
+		exp      string
+	}{
+		{
+			template: "GET /v1/items/{id:[0-9]+}",
+			pattern:  "GET /v1/items/{id}",
+			exp:      "/v1/items/42",
+		},
+		{
+			template: "example.com/v1/items/{id}",
+			pattern:  "example.com/v1/items/{id}",
+			exp:      "/v1/items/42",
+		},
+		{
+			template: "POST  example.com/v1/items/{id}",
+			pattern:  "POST  example.com/v1/items/{id}",
+			exp:      "/v1/items/42",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.template, func(t *testing.T) {
+			r := MustRoute(tc.template, nil)
+			must.EqOp(t, tc.template, r.String())
+			must.EqOp(t, tc.pattern, r.Pattern())
+			must.Eq(t, []Parameter{"id"}, r.Parameters())
+
+			link, err := r.Build(map[string]string{"id": "42"})
+			must.NoError(t, err)
+			must.EqOp(t, tc.exp, link)
+		})
+	}
+
+	router := http.NewServeMux()
+	r := MustRoute("GET /v1/items/{id}", nil)
+	executed := false
+	router.HandleFunc(r.Pattern(), func(http.ResponseWriter, *http.Request) {
+		executed = true
+	})
+
+	link, err := r.Build(map[string]string{"id": "42"})
+	must.NoError(t, err)
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, link, nil)
+	must.NoError(t, err)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+	must.True(t, executed)
+}
+
+func Test_NewRoute_invalid(t *testing.T) {
+	var s string
+
+	cases := []struct {
+		name     string
+		template string
+		schema   Schema
+		exp      string
+	}{
+		{
+			name:     "unclosed",
+			template: "/v1/{id",
+			exp:      `invalid route "/v1/{id": unclosed '{' at offset 4`,
+		},
+		{
+			name:     "unexpected",
+			template: "/v1/id}",
+			exp:      `invalid route "/v1/id}": unexpected '}' at offset 6`,
+		},
+		{
+			name:     "method without path",
+			template: "GET items",
+			exp:      `invalid route "GET items": missing path after method`,
+		},
+		{
+			name:     "parameter in host",
+			template: "{host}.example.com/v1",
+			exp:      `invalid route "{host}.example.com/v1": parameters are not allowed in the method or host`,
+		},
+		{
+			name:     "bad name",
+			template: "/v1/{1d}",
+			exp:      `invalid route "/v1/{1d}": invalid parameter name "1d"`,
+		},
+		{
+			name:     "bad pattern",
+			template: "/v1/{id:a(b}",
+			exp:      "invalid route \"/v1/{id:a(b}\": parameter \"id\": error parsing regexp: missing closing ): `a(b`",
+		},
+		{
+			name:     "duplicate",
+			template: "/v1/{id}/{id}",
+			exp:      `invalid route "/v1/{id}/{id}": parameter "id" is declared more than once`,
+		},
+		{
+			name:     "not in schema",
+			template: "/v1/{id}",
+			schema:   Schema{"other": String(&s)},
+			exp:      `invalid route "/v1/{id}": parameter "id" is not in schema`,
+		},
+		{
+			name:     "not in route",
+			template: "/v1/{id}",
+			schema:   Schema{"id": String(&s), "other": String(&s)},
+			exp:      `invalid route "/v1/{id}": schema parameter "other" is not in route and is not optional`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRoute(tc.template, tc.schema)
+			must.EqError(t, err, tc.exp)
+		})
+	}
+}
+
+func Test_NewRoute_optional(t *testing.T) {
+	var id int
+	schema := Schema{"id": IntOr(&id, 0)}
+
+	_, err := NewRoute("/v1/items", schema)
+	must.NoError(t, err)
+
+	_, err = NewRoute("/v1/items/{id}", schema)
+	must.NoError(t, err)
+}
+
+func Test_MustRoute_panics(t *testing.T) {
+	defer func() {
+		must.NotNil(t, recover())
+	}()
+	MustRoute("/v1/{", nil)
+}
+
+func Test_Route_Build(t *testing.T) {
+	r := MustRoute("/v1/{kind}/{id:[0-9]+}/{path...}", nil)
+
+	link, err := r.Build(map[string]string{
+		"kind": "a b/c",
+		"id":   "42",
+		"path": "x y/z",
+	})
+	must.NoError(t, err)
+	must.EqOp(t, "/v1/a%20b%2Fc/42/x%20y/z", link)
+
+	cases := []struct {
+		name   string
+		values map[string]string
+		exp    string
+	}{
+		{
+			name:   "missing",
+			values: map[string]string{"kind": "a", "path": "p"},
+			exp:    `url path element not present: "{id}"`,
+		},
+		{
+			name:   "empty",
+			values: map[string]string{"kind": "", "id": "1", "path": "p"},
+			exp:    `url path element is empty: "{kind}"`,
+		},
+		{
+			name:   "mismatch",
+			values: map[string]string{"kind": "a", "id": "x", "path": "p"},
+			exp:    `url path element "{id}" does not match {id:[0-9]+}: "x"`,
+		},
+		{
+			name:   "unknown",
+			values: map[string]string{"kind": "a", "id": "1", "path": "p", "other": "o"},
+			exp:    `url path element is not in route: "other"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := r.Build(tc.values)
+			must.EqError(t, err, tc.exp)
+		})
+	}
+}
+
+func Test_Route_roundtrip(t *testing.T) {
+	var (
+		kind string
+		id   int
+	)
+
+	schema := Schema{
+		"kind": String(&kind),
+		"id":   Int(&id),
+	}
+	r := MustRoute("/v1/{kind}/{id:[0-9]+}", schema)
+
+	router := http.NewServeMux()
+	router.HandleFunc(r.Pattern(), func(_ http.ResponseWriter, req *http.Request) {
+		must.NoError(t, Parse(req, schema))
+	})
+
+	link, err := r.Build(map[string]string{"kind": "big book", "id": "42"})
+	must.NoError(t, err)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, link, nil)
+	must.NoError(t, err)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	must.EqOp(t, "big book", kind)
+	must.EqOp(t, 42, id)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type enumParser struct {
+	values      []string
+	destination *string
+}
+
+// Enum creates a Parser that will parse a path element into s, which must be
+// one of values. Otherwise an error wrapping ErrNotAllowed and listing values
+// is returned.
+//
+//	"kind": urlpath.Enum(&kind, "users", "groups", "roles")
+func Enum(s *string, values ...string) Parser {
+	return &enumParser{values: values, destination: s}
+}
+
+func (p *enumParser) Parse(s string) error {
+	if !slices.Contains(p.values, s) {
+		return fmt.Errorf("%w: %q is not one of %s", ErrNotAllowed, s, quote(p.values))
+	}
+	*p.destination = s
+	return nil
+}
+
+func quote(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+type matchParser struct {
+	expr        string
+	pattern     *regexp.Regexp
+	destination *string
+}
+
+// Match creates a Parser that will parse a path element into s, which must
+// match re in its entirety. Otherwise an error wrapping ErrNoMatch and
+// including the pattern is returned.
+//
+//	"slug": urlpath.Match(&slug, regexp.MustCompile(`[a-z0-9-]+`))
+func Match(s *string, re *regexp.Regexp) Parser {
+	return &matchParser{
+		expr:        re.String(),
+		pattern:     regexp.MustCompile("^(?:" + re.String() + ")$"),
+		destination: s,
+	}
+}
+
+func (p *matchParser) Parse(s string) error {
+	if !p.pattern.MatchString(s) {
+		return fmt.Errorf("%w: %q does not match %q", ErrNoMatch, s, p.expr)
+	}
+	*p.destination = s
+	return nil
+}
+
+type lengthParser struct {
+	lo, hi      int
+	destination *string
+}
+
+// StringLen creates a Parser that will parse a path element into s, which
+// must have a length (in runes) within the inclusive range of lo to hi.
+// Otherwise an error wrapping ErrInvalidLength is returned.
+func StringLen(s *string, lo, hi int) Parser {
+	return &lengthParser{lo: lo, hi: hi, destination: s}
+}
+
+func (p *lengthParser) Parse(s string) error {
+	if n := utf8.RuneCountInString(s); n < p.lo || n > p.hi {
+		return fmt.Errorf("%w: %q has length %d, not between %d and %d", ErrInvalidLength, s, n, p.lo, p.hi)
+	}
+	*p.destination = s
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Seth Hoenig
+// SPDX-License-Identifier: BSD-3-Clause
+
+package urlpath
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_ParseValues_strings(t *testing.T) {
+	var kind, slug, code string
+
+	err := ParseValues(map[string]string{
+		"kind": "groups",
+		"slug": "hello-world-2",
+		"code": "αβγ",
+	}, Schema{
+		"kind": Enum(&kind, "users", "groups", "roles"),
+		"slug": Match(&slug, regexp.MustCompile(`[a-z0-9-]+`)),
+		"code": StringLen(&code, 2, 3),
+	})
+	must.NoError(t, err)
+	must.EqOp(t, "groups", kind)
+	must.EqOp(t, "hello-world-2", slug)
+	must.EqOp(t, "αβγ", code)
+}
+
+func Test_ParseValues_strings_fail(t *testing.T) {
+	var s string
+
+	cases := []struct {
+		name   string
+		value  string
+		parser Parser
+		is     error
+		exp    string
+	}{
+		{
+			name:   "enum",
+			value:  "admins",
+			parser: Enum(&s, "users", "groups"),
+			is:     ErrNotAllowed,
+			exp:    `could not parse url path variable "{x}": value is not allowed: "admins" is not one of ["users", "groups"]`,
+		},
+		{
+			name:   "enum empty",
+			value:  "",
+			parser: Enum(&s, "users", "groups"),
+			is:     ErrNotAllowed,
+			exp:    `could not parse url path variable "{x}": value is not allowed: "" is not one of ["users", "groups"]`,
+		},
+		{
+			name:   "match",
+			value:  "Hello-World",
+			parser: Match(&s, regexp.MustCompile(`[a-z0-9-]+`)),
+			is:     ErrNoMatch,
+			exp:    `could not parse url path variable "{x}": value does not match pattern: "Hello-World" does not match "[a-z0-9-]+"`,
+		},
+		{
+			name:   "match alternation",
+			value:  "ab",
+			parser: Match(&s, regexp.MustCompile(`a|b`)),
+			is:     ErrNoMatch,
+			exp:    `could not parse url path variable "{x}": value does not match pattern: "ab" does not match "a|b"`,
+		},
+		{
+			name:   "too short",
+			value:  "",
+			parser: StringLen(&s, 1, 3),
+			is:     ErrInvalidLength,
+			exp:    `could not parse url path variable "{x}": value has invalid length: "" has length 0, not between 1 and 3`,
+		},
+		{
+			name:   "too long",
+			value:  "abcd",
+			parser: StringLen(&s, 1, 3),
+			is:     ErrInvalidLength,
+			exp:    `could not parse url path variable "{x}": value has invalid length: "abcd" has length 4, not between 1 and 3`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ParseValues(map[string]string{"x": tc.value}, Schema{
+				"x": tc.parser,
+			})
+			must.ErrorIs(t, err, tc.is)
+			must.EqError(t, err, tc.exp)
+
+			var pe *ParameterError
+			must.True(t, errors.As(err, &pe))
+			must.EqOp(t, "x", pe.Parameter)
+			must.EqOp(t, "", s)
+		})
+	}
+}